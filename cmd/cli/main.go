@@ -2,7 +2,6 @@ package main
 
 import (
 	"fmt"
-	"math"
 	"os"
 
 	runnerzero "github.com/NethermindEth/cairo-vm-go/pkg/runners/zero"
@@ -11,7 +10,9 @@ import (
 
 func main() {
 	var proofmode bool
+	var secureRun bool
 	var maxsteps uint64
+	var availableGas uint64
 	var traceLocation string
 	var memoryLocation string
 
@@ -32,14 +33,27 @@ func main() {
 						Required:    false,
 						Destination: &proofmode,
 					},
+					&cli.BoolFlag{
+						Name:        "securerun",
+						Usage:       "validates the memory once the execution has finished",
+						Required:    false,
+						Destination: &secureRun,
+					},
 					&cli.Uint64Flag{
 						Name:        "maxsteps",
-						Usage:       "limits the execution steps to 'maxsteps'",
-						DefaultText: "2**64 - 1",
-						Value:       math.MaxUint64,
+						Usage:       "limits the execution steps to 'maxsteps', 0 means no limit",
+						DefaultText: "no limit",
+						Value:       0,
 						Required:    false,
 						Destination: &maxsteps,
 					},
+					&cli.Uint64Flag{
+						Name:        "availablegas",
+						Usage:       "gas the execution starts with, consumed by cairo 1 programs",
+						Value:       0,
+						Required:    false,
+						Destination: &availableGas,
+					},
 					&cli.StringFlag{
 						Name:        "tracefile",
 						Usage:       "location to store the relocated trace",
@@ -70,10 +84,11 @@ func main() {
 					}
 
 					fmt.Println("Running....")
-					runner, err := runnerzero.NewRunner(program, proofmode, maxsteps)
+					runner, err := runnerzero.NewRunner(program, proofmode, secureRun, maxsteps)
 					if err != nil {
 						return fmt.Errorf("cannot create runner: %w", err)
 					}
+					runner.SetRunOptions(runnerzero.RunOptions{AvailableGas: availableGas})
 
 					if err := runner.Run(); err != nil {
 						return fmt.Errorf("runtime error: %w", err)
@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"testing"
 
@@ -167,18 +168,22 @@ func runVm(path string) (string, string, string, error) {
 
 }
 
-func decodeProof(traceLocation string, memoryLocation string) ([]vm.Trace, []*fp.Element, error) {
+func decodeProof(traceLocation string, memoryLocation string) ([]vm.Trace, map[uint64]*fp.Element, error) {
 	trace, err := os.ReadFile(traceLocation)
 	if err != nil {
 		return nil, nil, err
 	}
 	decodedTrace := vm.DecodeTrace(trace)
 
-	memory, err := os.ReadFile(memoryLocation)
+	memory, err := os.Open(memoryLocation)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer memory.Close()
+	decodedMemory, err := vm.DecodeMemory(memory)
 	if err != nil {
 		return nil, nil, err
 	}
-	decodedMemory := vm.DecodeMemory(memory)
 
 	return decodedTrace, decodedMemory, nil
 }
@@ -229,10 +234,16 @@ func traceRepr(trace []vm.Trace) string {
 	return strings.Join(repr, ", ")
 }
 
-func memoryRepr(memory []*fp.Element) string {
-	repr := make([]string, len(memory))
-	for i, felt := range memory {
-		repr[i] = felt.Text(10)
+func memoryRepr(memory map[uint64]*fp.Element) string {
+	addresses := make([]uint64, 0, len(memory))
+	for address := range memory {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool { return addresses[i] < addresses[j] })
+
+	repr := make([]string, len(addresses))
+	for i, address := range addresses {
+		repr[i] = fmt.Sprintf("%d: %s", address, memory[address].Text(10))
 	}
 	return strings.Join(repr, ", ")
 
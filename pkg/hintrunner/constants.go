@@ -1,7 +1,25 @@
 package hintrunner
 
-import "github.com/holiman/uint256"
+import (
+	"math/big"
+
+	"github.com/holiman/uint256"
+)
 
 func MaxU128() uint256.Int {
 	return uint256.Int{18446744073709551615, 18446744073709551615, 0, 0}
 }
+
+// 2**251 - 256, StarkNet addresses must be strictly smaller
+var starknetAddressBound = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 251), big.NewInt(256))
+
+// 2**160, Ethereum addresses must be strictly smaller
+var ethAddressBound = new(big.Int).Lsh(big.NewInt(1), 160)
+
+// 2**251, StarkNet class hashes must be strictly smaller
+var classHashBound = new(big.Int).Lsh(big.NewInt(1), 251)
+
+// High 128 bits of PRIME // 3 and PRIME // 2, rounded up, as defined by the Cairo
+// standard library to range check the arcs of `assert_le_felt`
+var primeOver3High, _ = new(big.Int).SetString("3544607988759775765608368578435044694", 10)
+var primeOver2High, _ = new(big.Int).SetString("5316911983139663648412552867652567041", 10)
@@ -0,0 +1,176 @@
+package hintrunner
+
+import (
+	"fmt"
+
+	VM "github.com/NethermindEth/cairo-vm-go/pkg/vm"
+	"github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
+	f "github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+)
+
+// Holds the state hints share along an execution
+type HintRunnerContext struct {
+	DictionaryManager DictionaryManager
+	// variables written by a hint to be read by later ones, indexed by name
+	scope map[string]any
+	// ap as recorded by the last RecordAp or AssertApDelta hint, nil if none ran yet
+	previousAp *uint64
+	// gas left for the execution, consumed by the ConsumeGas hint
+	availableGas uint64
+}
+
+func NewHintRunnerContext() *HintRunnerContext {
+	return &HintRunnerContext{
+		DictionaryManager: DictionaryManager{
+			dictionaries: make(map[uint64]*Dictionary),
+		},
+		scope: make(map[string]any),
+	}
+}
+
+// Stores a variable in the scope, overwriting any previous value with the same name
+func (ctx *HintRunnerContext) SetVariable(name string, value any) {
+	ctx.scope[name] = value
+}
+
+// Returns the value of a variable in the scope, errors if it was never set
+func (ctx *HintRunnerContext) GetVariable(name string) (any, error) {
+	value, ok := ctx.scope[name]
+	if !ok {
+		return nil, fmt.Errorf("variable %s not found in scope", name)
+	}
+	return value, nil
+}
+
+// Returns the value of a variable in the scope, errors if it was never set or if it
+// holds a value of another type
+func getVariableAs[T any](ctx *HintRunnerContext, name string) (T, error) {
+	var typed T
+	value, err := ctx.GetVariable(name)
+	if err != nil {
+		return typed, err
+	}
+	typed, ok := value.(T)
+	if !ok {
+		return typed, fmt.Errorf("variable %s holds a %T instead of a %T", name, value, typed)
+	}
+	return typed, nil
+}
+
+// Sets the gas available for the rest of the execution
+func (ctx *HintRunnerContext) SetAvailableGas(gas uint64) {
+	ctx.availableGas = gas
+}
+
+func (ctx *HintRunnerContext) AvailableGas() uint64 {
+	return ctx.availableGas
+}
+
+// Subtracts an amount from the available gas, errors without consuming any if
+// there is not enough gas left
+func (ctx *HintRunnerContext) ConsumeGas(amount uint64) error {
+	if amount > ctx.availableGas {
+		return fmt.Errorf("out of gas: %d needed but only %d available", amount, ctx.availableGas)
+	}
+	ctx.availableGas -= amount
+	return nil
+}
+
+// A dictionary created during the execution, its accesses are written by the
+// program in its own segment
+type Dictionary struct {
+	// current value of every key written so far
+	data map[f.Element]memory.MemoryValue
+	// value of the keys never written
+	defaultValue memory.MemoryValue
+	// segment the dictionary accesses were allocated in
+	segmentIndex uint64
+	// number of reads and writes done so far
+	accessCount uint64
+	// set once the dictionary accesses have been squashed
+	squashed bool
+}
+
+// Returns the current value of a key, or the default value if it was never written
+func (dict *Dictionary) Read(key *f.Element) memory.MemoryValue {
+	dict.accessCount++
+	return dict.Peek(key)
+}
+
+// Returns the current value of a key like Read, without counting it as an access
+func (dict *Dictionary) Peek(key *f.Element) memory.MemoryValue {
+	if value, ok := dict.data[*key]; ok {
+		return value
+	}
+	return dict.defaultValue
+}
+
+// Sets the value of a key and returns the one it had before
+func (dict *Dictionary) Write(key *f.Element, value *memory.MemoryValue) memory.MemoryValue {
+	prevValue := dict.Read(key)
+	dict.data[*key] = *value
+	return prevValue
+}
+
+func (dict *Dictionary) SegmentIndex() uint64 {
+	return dict.segmentIndex
+}
+
+func (dict *Dictionary) AccessCount() uint64 {
+	return dict.accessCount
+}
+
+func (dict *Dictionary) Squashed() bool {
+	return dict.squashed
+}
+
+// Marks the dictionary as squashed, errors if it already was
+func (dict *Dictionary) Squash() error {
+	if dict.squashed {
+		return fmt.Errorf("dictionary has already been squashed")
+	}
+	dict.squashed = true
+	return nil
+}
+
+// Keeps track of every dictionary, indexed by the segment holding its accesses
+type DictionaryManager struct {
+	dictionaries map[uint64]*Dictionary
+}
+
+// Creates a new empty dictionary and allocates a segment for its accesses,
+// returning the address where they start
+func (dm *DictionaryManager) NewDictionary(vm *VM.VirtualMachine, defaultValue memory.MemoryValue) memory.MemoryAddress {
+	segment := uint64(vm.Memory.AllocateEmptySegment())
+	dm.dictionaries[segment] = &Dictionary{
+		data:         make(map[f.Element]memory.MemoryValue),
+		defaultValue: defaultValue,
+		segmentIndex: segment,
+	}
+	return memory.MemoryAddress{SegmentIndex: segment, Offset: 0}
+}
+
+// Reindexes the dictionaries after the memory segments were compacted, given the new
+// index of every segment, -1 for the dropped ones. Dictionaries whose segment was
+// dropped never had an access written, so they are dropped as well
+func (dm *DictionaryManager) RemapSegments(newIndices []int) {
+	dictionaries := make(map[uint64]*Dictionary, len(dm.dictionaries))
+	for _, dict := range dm.dictionaries {
+		newIndex := newIndices[dict.segmentIndex]
+		if newIndex < 0 {
+			continue
+		}
+		dict.segmentIndex = uint64(newIndex)
+		dictionaries[dict.segmentIndex] = dict
+	}
+	dm.dictionaries = dictionaries
+}
+
+// Given a pointer into the accesses segment of a dictionary returns such dictionary
+func (dm *DictionaryManager) GetDictionary(dictPtr *memory.MemoryAddress) (*Dictionary, error) {
+	dict, ok := dm.dictionaries[dictPtr.SegmentIndex]
+	if !ok {
+		return nil, fmt.Errorf("no dictionary at segment %d", dictPtr.SegmentIndex)
+	}
+	return dict, nil
+}
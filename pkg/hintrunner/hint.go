@@ -2,18 +2,26 @@ package hintrunner
 
 import (
 	"fmt"
+	"math/big"
+	"sort"
 
 	"github.com/holiman/uint256"
 
 	VM "github.com/NethermindEth/cairo-vm-go/pkg/vm"
+	"github.com/NethermindEth/cairo-vm-go/pkg/vm/builtins"
 	"github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	blsfp "github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	starkcurve "github.com/consensys/gnark-crypto/ecc/stark-curve"
 	f "github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+	pedersenhash "github.com/consensys/gnark-crypto/ecc/stark-curve/pedersen-hash"
+	"golang.org/x/crypto/sha3"
 )
 
 type Hinter interface {
 	fmt.Stringer
 
-	Execute(vm *VM.VirtualMachine) error
+	Execute(vm *VM.VirtualMachine, ctx *HintRunnerContext) error
 }
 
 type AllocSegment struct {
@@ -24,7 +32,7 @@ func (hint AllocSegment) String() string {
 	return "AllocSegment"
 }
 
-func (hint AllocSegment) Execute(vm *VM.VirtualMachine) error {
+func (hint AllocSegment) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
 	segmentIndex := vm.Memory.AllocateEmptySegment()
 	memAddress := memory.MemoryValueFromSegmentAndOffset(segmentIndex, 0)
 
@@ -51,7 +59,7 @@ func (hint TestLessThan) String() string {
 	return "TestLessThan"
 }
 
-func (hint TestLessThan) Execute(vm *VM.VirtualMachine) error {
+func (hint TestLessThan) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
 	lhsVal, err := hint.lhs.Resolve(vm)
 	if err != nil {
 		return fmt.Errorf("resolve lhs operand %s: %w", hint.lhs, err)
@@ -101,7 +109,7 @@ func (hint TestLessThanOrEqual) String() string {
 	return "TestLessThanOrEqual"
 }
 
-func (hint TestLessThanOrEqual) Execute(vm *VM.VirtualMachine) error {
+func (hint TestLessThanOrEqual) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
 	lhsVal, err := hint.lhs.Resolve(vm)
 	if err != nil {
 		return fmt.Errorf("resolve lhs operand %s: %w", hint.lhs, err)
@@ -152,7 +160,7 @@ func (hint WideMul128) String() string {
 	return "WideMul128"
 }
 
-func (hint WideMul128) Execute(vm *VM.VirtualMachine) error {
+func (hint WideMul128) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
 	mask := MaxU128()
 
 	lhs, err := hint.lhs.Resolve(vm)
@@ -221,7 +229,7 @@ type DebugPrint struct {
 	end   ResOperander
 }
 
-func (hint DebugPrint) Execute(vm *VM.VirtualMachine) error {
+func (hint DebugPrint) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
 	start, err := hint.start.Resolve(vm)
 	if err != nil {
 		return fmt.Errorf("resolve start operand %s: %v", hint.start, err)
@@ -272,7 +280,7 @@ func (hint SquareRoot) String() string {
 	return "SquareRoot"
 }
 
-func (hint SquareRoot) Execute(vm *VM.VirtualMachine) error {
+func (hint SquareRoot) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
 	value, err := hint.value.Resolve(vm)
 	if err != nil {
 		return fmt.Errorf("resolve value operand %s: %v", hint.value, err)
@@ -297,3 +305,3578 @@ func (hint SquareRoot) Execute(vm *VM.VirtualMachine) error {
 	}
 	return nil
 }
+
+// Splits a felt into 32 byte cells, most significant byte first, written from the
+// address bytes points to. BytesToFelt252 recombines them
+type Felt252ToBytes struct {
+	value ResOperander
+	bytes ResOperander
+}
+
+func (hint Felt252ToBytes) String() string {
+	return "Felt252ToBytes"
+}
+
+func (hint Felt252ToBytes) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	value, err := resolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand %s: %v", hint.value, err)
+	}
+	bytesAddr, err := resolveAsAddress(vm, hint.bytes)
+	if err != nil {
+		return fmt.Errorf("resolve bytes operand %s: %v", hint.bytes, err)
+	}
+
+	for i, b := range value.Bytes() {
+		byteValue := memory.MemoryValueFromUint(b)
+		err := vm.Memory.Write(bytesAddr.SegmentIndex, bytesAddr.Offset+uint64(i), &byteValue)
+		if err != nil {
+			return fmt.Errorf("write byte %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// Recombines 32 byte cells, most significant byte first, into a single felt
+type BytesToFelt252 struct {
+	bytes ResOperander
+	dst   CellRefer
+}
+
+func (hint BytesToFelt252) String() string {
+	return "BytesToFelt252"
+}
+
+func (hint BytesToFelt252) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	bytesAddr, err := resolveAsAddress(vm, hint.bytes)
+	if err != nil {
+		return fmt.Errorf("resolve bytes operand %s: %v", hint.bytes, err)
+	}
+
+	var bytes [32]byte
+	for i := range bytes {
+		mv, err := vm.Memory.Read(bytesAddr.SegmentIndex, bytesAddr.Offset+uint64(i))
+		if err != nil {
+			return fmt.Errorf("read byte %d: %v", i, err)
+		}
+		byteValue, err := mv.Uint64()
+		if err != nil || byteValue > 255 {
+			return fmt.Errorf("value %s at position %d is not a byte", mv, i)
+		}
+		bytes[i] = byte(byteValue)
+	}
+
+	value := new(big.Int).SetBytes(bytes[:])
+	if value.Cmp(f.Modulus()) >= 0 {
+		return fmt.Errorf("recombined value %s exceeds the field modulus", value)
+	}
+
+	felt := f.Element{}
+	felt.SetBigInt(value)
+	return writeFelt(vm, hint.dst, &felt)
+}
+
+// Computes (a + b) mod n where every operand is an u256 given as low and high limbs
+type Uint256AddMod struct {
+	aLow       ResOperander
+	aHigh      ResOperander
+	bLow       ResOperander
+	bHigh      ResOperander
+	nLow       ResOperander
+	nHigh      ResOperander
+	resultLow  CellRefer
+	resultHigh CellRefer
+}
+
+func (hint Uint256AddMod) String() string {
+	return "Uint256AddMod"
+}
+
+func (hint Uint256AddMod) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	a, err := resolveAsUint256(vm, hint.aLow, hint.aHigh)
+	if err != nil {
+		return fmt.Errorf("resolve a operand: %v", err)
+	}
+	b, err := resolveAsUint256(vm, hint.bLow, hint.bHigh)
+	if err != nil {
+		return fmt.Errorf("resolve b operand: %v", err)
+	}
+	n, err := resolveAsUint256(vm, hint.nLow, hint.nHigh)
+	if err != nil {
+		return fmt.Errorf("resolve n operand: %v", err)
+	}
+
+	if n.Sign() == 0 {
+		return fmt.Errorf("modulus cannot be zero")
+	}
+
+	result := new(big.Int).Add(a, b)
+	result.Mod(result, n)
+	return writeUint256(vm, hint.resultLow, hint.resultHigh, result)
+}
+
+// Computes (a - b) mod n where every operand is an u256 given as low and high limbs.
+// When a < b the difference wraps by adding n, so the result always lies in [0, n)
+type Uint256SubMod struct {
+	aLow       ResOperander
+	aHigh      ResOperander
+	bLow       ResOperander
+	bHigh      ResOperander
+	nLow       ResOperander
+	nHigh      ResOperander
+	resultLow  CellRefer
+	resultHigh CellRefer
+}
+
+func (hint Uint256SubMod) String() string {
+	return "Uint256SubMod"
+}
+
+func (hint Uint256SubMod) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	a, err := resolveAsUint256(vm, hint.aLow, hint.aHigh)
+	if err != nil {
+		return fmt.Errorf("resolve a operand: %v", err)
+	}
+	b, err := resolveAsUint256(vm, hint.bLow, hint.bHigh)
+	if err != nil {
+		return fmt.Errorf("resolve b operand: %v", err)
+	}
+	n, err := resolveAsUint256(vm, hint.nLow, hint.nHigh)
+	if err != nil {
+		return fmt.Errorf("resolve n operand: %v", err)
+	}
+
+	if n.Sign() == 0 {
+		return fmt.Errorf("modulus cannot be zero")
+	}
+
+	// big.Int.Mod is euclidean, negative differences wrap to [0, n)
+	result := new(big.Int).Sub(a, b)
+	result.Mod(result, n)
+	return writeUint256(vm, hint.resultLow, hint.resultHigh, result)
+}
+
+// Computes the scalar multiplication k * P over the stark curve. The point at
+// infinity is represented as (0, 0) both as input and as output
+type EcMul struct {
+	x      ResOperander
+	y      ResOperander
+	scalar ResOperander
+	dstX   CellRefer
+	dstY   CellRefer
+}
+
+func (hint EcMul) String() string {
+	return "EcMul"
+}
+
+func (hint EcMul) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	x, err := resolveAsFelt(vm, hint.x)
+	if err != nil {
+		return fmt.Errorf("resolve x operand %s: %v", hint.x, err)
+	}
+	y, err := resolveAsFelt(vm, hint.y)
+	if err != nil {
+		return fmt.Errorf("resolve y operand %s: %v", hint.y, err)
+	}
+	scalar, err := resolveAsFelt(vm, hint.scalar)
+	if err != nil {
+		return fmt.Errorf("resolve scalar operand %s: %v", hint.scalar, err)
+	}
+
+	point := starkcurve.G1Affine{X: *x, Y: *y}
+	if !point.IsOnCurve() {
+		return fmt.Errorf("point (%s, %s) is not on the curve", x, y)
+	}
+
+	// double and add, starting from the most significant bit
+	k := scalar.BigInt(new(big.Int))
+	result := starkcurve.G1Jac{}
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		result.DoubleAssign()
+		if k.Bit(i) == 1 {
+			result.AddMixed(&point)
+		}
+	}
+
+	resultAffine := starkcurve.G1Affine{}
+	resultAffine.FromJacobian(&result)
+
+	if err := writeFelt(vm, hint.dstX, &resultAffine.X); err != nil {
+		return err
+	}
+	return writeFelt(vm, hint.dstY, &resultAffine.Y)
+}
+
+// Verifies a stark curve ECDSA signature (r, s) of a message hash given the x coordinate
+// of the public key. Errors if the signature is invalid
+type EcdsaVerify struct {
+	publicKey ResOperander
+	message   ResOperander
+	r         ResOperander
+	s         ResOperander
+}
+
+func (hint EcdsaVerify) String() string {
+	return "EcdsaVerify"
+}
+
+func (hint EcdsaVerify) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	publicKey, err := resolveAsFelt(vm, hint.publicKey)
+	if err != nil {
+		return fmt.Errorf("resolve public key operand %s: %v", hint.publicKey, err)
+	}
+	message, err := resolveAsFelt(vm, hint.message)
+	if err != nil {
+		return fmt.Errorf("resolve message operand %s: %v", hint.message, err)
+	}
+	r, err := resolveAsFelt(vm, hint.r)
+	if err != nil {
+		return fmt.Errorf("resolve r operand %s: %v", hint.r, err)
+	}
+	s, err := resolveAsFelt(vm, hint.s)
+	if err != nil {
+		return fmt.Errorf("resolve s operand %s: %v", hint.s, err)
+	}
+
+	valid, err := builtins.VerifyEcdsa(publicKey, message, r, s)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return fmt.Errorf("invalid signature (%s, %s) for message %s", r, s, message)
+	}
+	return nil
+}
+
+// Clamps a value into the [min, max] range, interpreting all operands as field integers
+type Clamp struct {
+	value ResOperander
+	min   ResOperander
+	max   ResOperander
+	dst   CellRefer
+}
+
+func (hint Clamp) String() string {
+	return "Clamp"
+}
+
+func (hint Clamp) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	value, err := resolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand %s: %v", hint.value, err)
+	}
+	min, err := resolveAsFelt(vm, hint.min)
+	if err != nil {
+		return fmt.Errorf("resolve min operand %s: %v", hint.min, err)
+	}
+	max, err := resolveAsFelt(vm, hint.max)
+	if err != nil {
+		return fmt.Errorf("resolve max operand %s: %v", hint.max, err)
+	}
+
+	if min.Cmp(max) > 0 {
+		return fmt.Errorf("min %s cannot be greater than max %s", min, max)
+	}
+
+	result := value
+	if result.Cmp(min) < 0 {
+		result = min
+	} else if result.Cmp(max) > 0 {
+		result = max
+	}
+	return writeFelt(vm, hint.dst, result)
+}
+
+// Counts the non-zero cells found from a pointer until the first zero felt. The scan fails
+// if no terminator is found within the first maxLength cells
+type StrLen struct {
+	str       ResOperander
+	maxLength ResOperander
+	dst       CellRefer
+}
+
+func (hint StrLen) String() string {
+	return "StrLen"
+}
+
+func (hint StrLen) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	strAddr, err := resolveAsAddress(vm, hint.str)
+	if err != nil {
+		return fmt.Errorf("resolve str operand %s: %v", hint.str, err)
+	}
+	maxLength, err := resolveAsUint64(vm, hint.maxLength)
+	if err != nil {
+		return fmt.Errorf("resolve max length operand %s: %v", hint.maxLength, err)
+	}
+
+	length := uint64(0)
+	for ; length <= maxLength; length++ {
+		mv, err := vm.Memory.Read(strAddr.SegmentIndex, strAddr.Offset+length)
+		if err != nil {
+			return fmt.Errorf("read character %d: %v", length, err)
+		}
+		felt, err := mv.FieldElement()
+		if err != nil {
+			return fmt.Errorf("read character %d: %v", length, err)
+		}
+		if felt.IsZero() {
+			lengthFelt := new(f.Element).SetUint64(length)
+			return writeFelt(vm, hint.dst, lengthFelt)
+		}
+	}
+	return fmt.Errorf("no terminator found within %d cells", maxLength)
+}
+
+// Computes the remainder of dividing a value by a small compile-time constant
+type ModConst struct {
+	value    ResOperander
+	constant ResOperander
+	dst      CellRefer
+}
+
+func (hint ModConst) String() string {
+	return "ModConst"
+}
+
+func (hint ModConst) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	value, err := resolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand %s: %v", hint.value, err)
+	}
+	constant, err := resolveAsUint64(vm, hint.constant)
+	if err != nil {
+		return fmt.Errorf("resolve constant operand %s: %v", hint.constant, err)
+	}
+	if constant == 0 {
+		return fmt.Errorf("cannot divide by zero")
+	}
+
+	remainder := value.BigInt(new(big.Int))
+	remainder.Mod(remainder, new(big.Int).SetUint64(constant))
+
+	remainderFelt := f.Element{}
+	remainderFelt.SetBigInt(remainder)
+	return writeFelt(vm, hint.dst, &remainderFelt)
+}
+
+// Asserts that the sum of a range of felts equals an expected value
+type AssertSumEq struct {
+	start    ResOperander
+	length   ResOperander
+	expected ResOperander
+}
+
+func (hint AssertSumEq) String() string {
+	return "AssertSumEq"
+}
+
+func (hint AssertSumEq) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	startAddr, err := resolveAsAddress(vm, hint.start)
+	if err != nil {
+		return fmt.Errorf("resolve start operand %s: %v", hint.start, err)
+	}
+	length, err := resolveAsUint64(vm, hint.length)
+	if err != nil {
+		return fmt.Errorf("resolve length operand %s: %v", hint.length, err)
+	}
+	expected, err := resolveAsFelt(vm, hint.expected)
+	if err != nil {
+		return fmt.Errorf("resolve expected operand %s: %v", hint.expected, err)
+	}
+
+	values, err := readFelts(vm, startAddr, length)
+	if err != nil {
+		return err
+	}
+
+	sum := f.Element{}
+	for _, value := range values {
+		sum.Add(&sum, value)
+	}
+
+	if !sum.Equal(expected) {
+		return fmt.Errorf("sum %s is different from the expected value %s", &sum, expected)
+	}
+	return nil
+}
+
+// Writes the larger of two operands interpreted as field integers
+type Max struct {
+	lhs ResOperander
+	rhs ResOperander
+	dst CellRefer
+}
+
+func (hint Max) String() string {
+	return "Max"
+}
+
+func (hint Max) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	lhs, err := resolveAsFelt(vm, hint.lhs)
+	if err != nil {
+		return fmt.Errorf("resolve lhs operand %s: %v", hint.lhs, err)
+	}
+	rhs, err := resolveAsFelt(vm, hint.rhs)
+	if err != nil {
+		return fmt.Errorf("resolve rhs operand %s: %v", hint.rhs, err)
+	}
+
+	if lhs.Cmp(rhs) >= 0 {
+		return writeFelt(vm, hint.dst, lhs)
+	}
+	return writeFelt(vm, hint.dst, rhs)
+}
+
+// Writes the smaller of two operands interpreted as field integers
+type Min struct {
+	lhs ResOperander
+	rhs ResOperander
+	dst CellRefer
+}
+
+func (hint Min) String() string {
+	return "Min"
+}
+
+func (hint Min) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	lhs, err := resolveAsFelt(vm, hint.lhs)
+	if err != nil {
+		return fmt.Errorf("resolve lhs operand %s: %v", hint.lhs, err)
+	}
+	rhs, err := resolveAsFelt(vm, hint.rhs)
+	if err != nil {
+		return fmt.Errorf("resolve rhs operand %s: %v", hint.rhs, err)
+	}
+
+	if lhs.Cmp(rhs) <= 0 {
+		return writeFelt(vm, hint.dst, lhs)
+	}
+	return writeFelt(vm, hint.dst, rhs)
+}
+
+// Asserts that a pointer belongs to the segment of the given builtin
+type AssertBuiltinSegment struct {
+	ptr     ResOperander
+	builtin string
+}
+
+func (hint AssertBuiltinSegment) String() string {
+	return "AssertBuiltinSegment"
+}
+
+func (hint AssertBuiltinSegment) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	ptr, err := resolveAsAddress(vm, hint.ptr)
+	if err != nil {
+		return fmt.Errorf("resolve ptr operand %s: %v", hint.ptr, err)
+	}
+
+	if ptr.SegmentIndex >= uint64(len(vm.Memory.Segments)) {
+		return fmt.Errorf("pointer %s: segment %d is unallocated", ptr, ptr.SegmentIndex)
+	}
+
+	builtin := vm.Memory.Segments[ptr.SegmentIndex].BuiltinRunner.String()
+	if builtin != hint.builtin {
+		return fmt.Errorf(
+			"pointer %s does not belong to the %s builtin segment", ptr, hint.builtin,
+		)
+	}
+	return nil
+}
+
+// Writes the bit of a value at the given index, where index 0 is the least
+// significant bit. Indices outside of the felt bit size yield 0
+type GetBit struct {
+	value ResOperander
+	index ResOperander
+	dst   CellRefer
+}
+
+func (hint GetBit) String() string {
+	return "GetBit"
+}
+
+func (hint GetBit) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	value, err := resolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand %s: %v", hint.value, err)
+	}
+	index, err := resolveAsFelt(vm, hint.index)
+	if err != nil {
+		return fmt.Errorf("resolve index operand %s: %v", hint.index, err)
+	}
+
+	bit := f.Element{}
+	if index.IsUint64() && index.Uint64() < f.Bits {
+		bit.SetUint64(uint64(value.BigInt(new(big.Int)).Bit(int(index.Uint64()))))
+	}
+	return writeFelt(vm, hint.dst, &bit)
+}
+
+// Writes the value with the bit at the given index set or cleared depending on flag
+type SetBit struct {
+	value ResOperander
+	index ResOperander
+	flag  ResOperander
+	dst   CellRefer
+}
+
+func (hint SetBit) String() string {
+	return "SetBit"
+}
+
+func (hint SetBit) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	value, err := resolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand %s: %v", hint.value, err)
+	}
+	index, err := resolveAsUint64(vm, hint.index)
+	if err != nil {
+		return fmt.Errorf("resolve index operand %s: %v", hint.index, err)
+	}
+	flag, err := resolveAsUint64(vm, hint.flag)
+	if err != nil {
+		return fmt.Errorf("resolve flag operand %s: %v", hint.flag, err)
+	}
+
+	if index >= f.Bits {
+		return fmt.Errorf("index %d should be smaller than %d", index, f.Bits)
+	}
+	if flag > 1 {
+		return fmt.Errorf("flag %d should be either 0 or 1", flag)
+	}
+
+	result := value.BigInt(new(big.Int))
+	result.SetBit(result, int(index), uint(flag))
+	if result.Cmp(f.Modulus()) >= 0 {
+		return fmt.Errorf("result %s exceeds the field modulus", result)
+	}
+
+	resultFelt := f.Element{}
+	resultFelt.SetBigInt(result)
+	return writeFelt(vm, hint.dst, &resultFelt)
+}
+
+// Asserts that a value is a valid StarkNet address, i.e. smaller than 2**251 - 256
+type AssertStarknetAddress struct {
+	value ResOperander
+}
+
+func (hint AssertStarknetAddress) String() string {
+	return "AssertStarknetAddress"
+}
+
+func (hint AssertStarknetAddress) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	value, err := resolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand %s: %v", hint.value, err)
+	}
+
+	if value.BigInt(new(big.Int)).Cmp(starknetAddressBound) >= 0 {
+		return fmt.Errorf("value %s is not a valid StarkNet address", value)
+	}
+	return nil
+}
+
+// Decomposes a value into its base-N digits, least significant digit first, writing them
+// starting at the digits pointer, and the amount of digits written in the length cell.
+// Zero has no digits
+type ToBaseN struct {
+	value  ResOperander
+	base   ResOperander
+	digits ResOperander
+	length CellRefer
+}
+
+func (hint ToBaseN) String() string {
+	return "ToBaseN"
+}
+
+func (hint ToBaseN) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	value, err := resolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand %s: %v", hint.value, err)
+	}
+	base, err := resolveAsFelt(vm, hint.base)
+	if err != nil {
+		return fmt.Errorf("resolve base operand %s: %v", hint.base, err)
+	}
+	digitsAddr, err := resolveAsAddress(vm, hint.digits)
+	if err != nil {
+		return fmt.Errorf("resolve digits operand %s: %v", hint.digits, err)
+	}
+
+	baseBig := base.BigInt(new(big.Int))
+	if baseBig.Cmp(big.NewInt(2)) < 0 {
+		return fmt.Errorf("base %s should be at least 2", base)
+	}
+
+	remaining := value.BigInt(new(big.Int))
+	digit := new(big.Int)
+	count := uint64(0)
+	for ; remaining.Sign() > 0; count++ {
+		remaining.DivMod(remaining, baseBig, digit)
+
+		digitValue := memory.MemoryValueFromFieldElement(new(f.Element).SetBigInt(digit))
+		err := vm.Memory.Write(digitsAddr.SegmentIndex, digitsAddr.Offset+count, &digitValue)
+		if err != nil {
+			return fmt.Errorf("write digit %d: %v", count, err)
+		}
+	}
+
+	return writeFelt(vm, hint.length, new(f.Element).SetUint64(count))
+}
+
+// Recomposes a value from its base-N digits, least significant digit first. Errors
+// if any digit is not smaller than the base or if the value doesn't fit in a felt
+type FromBaseN struct {
+	digits ResOperander
+	base   ResOperander
+	length ResOperander
+	dst    CellRefer
+}
+
+func (hint FromBaseN) String() string {
+	return "FromBaseN"
+}
+
+func (hint FromBaseN) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	digitsAddr, err := resolveAsAddress(vm, hint.digits)
+	if err != nil {
+		return fmt.Errorf("resolve digits operand %s: %v", hint.digits, err)
+	}
+	base, err := resolveAsFelt(vm, hint.base)
+	if err != nil {
+		return fmt.Errorf("resolve base operand %s: %v", hint.base, err)
+	}
+	length, err := resolveAsUint64(vm, hint.length)
+	if err != nil {
+		return fmt.Errorf("resolve length operand %s: %v", hint.length, err)
+	}
+
+	baseBig := base.BigInt(new(big.Int))
+	if baseBig.Cmp(big.NewInt(2)) < 0 {
+		return fmt.Errorf("base %s should be at least 2", base)
+	}
+
+	digits, err := readFelts(vm, digitsAddr, length)
+	if err != nil {
+		return err
+	}
+
+	value := new(big.Int)
+	for i := len(digits) - 1; i >= 0; i-- {
+		digit := digits[i].BigInt(new(big.Int))
+		if digit.Cmp(baseBig) >= 0 {
+			return fmt.Errorf("digit %s at position %d is not smaller than base %s", digit, i, baseBig)
+		}
+		value.Mul(value, baseBig).Add(value, digit)
+	}
+
+	if value.Cmp(f.Modulus()) >= 0 {
+		return fmt.Errorf("recomposed value %s exceeds the field modulus", value)
+	}
+	return writeFelt(vm, hint.dst, new(f.Element).SetBigInt(value))
+}
+
+// Writes the sum of a value and an addend, clamped to bound. The operands are
+// interpreted as field integers and the sum is computed without wrapping around
+type SaturatingAdd struct {
+	value  ResOperander
+	addend ResOperander
+	bound  ResOperander
+	dst    CellRefer
+}
+
+func (hint SaturatingAdd) String() string {
+	return "SaturatingAdd"
+}
+
+func (hint SaturatingAdd) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	value, err := resolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand %s: %v", hint.value, err)
+	}
+	addend, err := resolveAsFelt(vm, hint.addend)
+	if err != nil {
+		return fmt.Errorf("resolve addend operand %s: %v", hint.addend, err)
+	}
+	bound, err := resolveAsFelt(vm, hint.bound)
+	if err != nil {
+		return fmt.Errorf("resolve bound operand %s: %v", hint.bound, err)
+	}
+
+	sum := value.BigInt(new(big.Int))
+	sum.Add(sum, addend.BigInt(new(big.Int)))
+	if sum.Cmp(bound.BigInt(new(big.Int))) >= 0 {
+		return writeFelt(vm, hint.dst, bound)
+	}
+
+	sumFelt := f.Element{}
+	sumFelt.SetBigInt(sum)
+	return writeFelt(vm, hint.dst, &sumFelt)
+}
+
+// Writes the difference between two operands interpreted as field integers, or zero
+// when the subtrahend is greater than the value
+type SaturatingSub struct {
+	value      ResOperander
+	subtrahend ResOperander
+	dst        CellRefer
+}
+
+func (hint SaturatingSub) String() string {
+	return "SaturatingSub"
+}
+
+func (hint SaturatingSub) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	value, err := resolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand %s: %v", hint.value, err)
+	}
+	subtrahend, err := resolveAsFelt(vm, hint.subtrahend)
+	if err != nil {
+		return fmt.Errorf("resolve subtrahend operand %s: %v", hint.subtrahend, err)
+	}
+
+	result := f.Element{}
+	if value.Cmp(subtrahend) > 0 {
+		result.Sub(value, subtrahend)
+	}
+	return writeFelt(vm, hint.dst, &result)
+}
+
+// Writes the number of trailing zero bits of a value. Zero yields the felt bit size
+type TrailingZeros struct {
+	value ResOperander
+	dst   CellRefer
+}
+
+func (hint TrailingZeros) String() string {
+	return "TrailingZeros"
+}
+
+func (hint TrailingZeros) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	value, err := resolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand %s: %v", hint.value, err)
+	}
+
+	count := uint64(f.Bits)
+	if !value.IsZero() {
+		count = uint64(value.BigInt(new(big.Int)).TrailingZeroBits())
+	}
+	return writeFelt(vm, hint.dst, new(f.Element).SetUint64(count))
+}
+
+// Asserts that every cell of a range is either 0 or 1
+type AssertAllBool struct {
+	start  ResOperander
+	length ResOperander
+}
+
+func (hint AssertAllBool) String() string {
+	return "AssertAllBool"
+}
+
+func (hint AssertAllBool) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	startAddr, err := resolveAsAddress(vm, hint.start)
+	if err != nil {
+		return fmt.Errorf("resolve start operand %s: %v", hint.start, err)
+	}
+	length, err := resolveAsUint64(vm, hint.length)
+	if err != nil {
+		return fmt.Errorf("resolve length operand %s: %v", hint.length, err)
+	}
+
+	values, err := readFelts(vm, startAddr, length)
+	if err != nil {
+		return err
+	}
+
+	for i, value := range values {
+		if !value.IsZero() && !value.IsOne() {
+			return fmt.Errorf("value %s at index %d is not a boolean", value, i)
+		}
+	}
+	return nil
+}
+
+// Rotates an u128 value to the left by the given amount of bits, bits shifted out of
+// the 128 bits are carried back on the right. The amount is taken modulo 128
+type RotateLeft128 struct {
+	value  ResOperander
+	amount ResOperander
+	dst    CellRefer
+}
+
+func (hint RotateLeft128) String() string {
+	return "RotateLeft128"
+}
+
+func (hint RotateLeft128) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	mask := MaxU128()
+
+	value, err := resolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand %s: %v", hint.value, err)
+	}
+	amount, err := resolveAsUint64(vm, hint.amount)
+	if err != nil {
+		return fmt.Errorf("resolve amount operand %s: %v", hint.amount, err)
+	}
+
+	valueU256 := uint256.Int(value.Bits())
+	if valueU256.Gt(&mask) {
+		return fmt.Errorf("value %s should be u128", value)
+	}
+
+	amount %= 128
+	left := new(uint256.Int).Lsh(&valueU256, uint(amount))
+	left.And(left, &mask)
+	right := new(uint256.Int).Rsh(&valueU256, uint(128-amount))
+	rotated := left.Or(left, right)
+
+	bytes := rotated.Bytes32()
+	result := f.Element{}
+	result.SetBytes(bytes[:])
+	return writeFelt(vm, hint.dst, &result)
+}
+
+// Writes the index of the most significant set bit of a value, where index 0 is the
+// least significant bit. Since zero has no set bit, a flag cell is set to 1 and the
+// index is written as 0 in that case, the flag is 0 otherwise
+type Msb struct {
+	value    ResOperander
+	dst      CellRefer
+	zeroFlag CellRefer
+}
+
+func (hint Msb) String() string {
+	return "Msb"
+}
+
+func (hint Msb) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	value, err := resolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand %s: %v", hint.value, err)
+	}
+
+	index := f.Element{}
+	flag := f.Element{}
+	if value.IsZero() {
+		flag.SetOne()
+	} else {
+		index.SetUint64(uint64(value.BigInt(new(big.Int)).BitLen() - 1))
+	}
+
+	if err := writeFelt(vm, hint.dst, &index); err != nil {
+		return err
+	}
+	return writeFelt(vm, hint.zeroFlag, &flag)
+}
+
+// Computes the dot product sum(a_i * b_i) in the field of two ranges of the same length
+type DotProduct struct {
+	lhs    ResOperander
+	rhs    ResOperander
+	length ResOperander
+	dst    CellRefer
+}
+
+func (hint DotProduct) String() string {
+	return "DotProduct"
+}
+
+func (hint DotProduct) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	lhsAddr, err := resolveAsAddress(vm, hint.lhs)
+	if err != nil {
+		return fmt.Errorf("resolve lhs operand %s: %v", hint.lhs, err)
+	}
+	rhsAddr, err := resolveAsAddress(vm, hint.rhs)
+	if err != nil {
+		return fmt.Errorf("resolve rhs operand %s: %v", hint.rhs, err)
+	}
+	length, err := resolveAsUint64(vm, hint.length)
+	if err != nil {
+		return fmt.Errorf("resolve length operand %s: %v", hint.length, err)
+	}
+
+	lhs, err := readFelts(vm, lhsAddr, length)
+	if err != nil {
+		return fmt.Errorf("read lhs: %v", err)
+	}
+	rhs, err := readFelts(vm, rhsAddr, length)
+	if err != nil {
+		return fmt.Errorf("read rhs: %v", err)
+	}
+
+	result := f.Element{}
+	product := f.Element{}
+	for i := range lhs {
+		product.Mul(lhs[i], rhs[i])
+		result.Add(&result, &product)
+	}
+	return writeFelt(vm, hint.dst, &result)
+}
+
+// Validates the access indices of the dictionary key being squashed, given as a pointer
+// to them and their amount. The delta between every access index and the previous one
+// must be non-negative
+type AssertAccessDeltasNonNegative struct {
+	accessIndices ResOperander
+	length        ResOperander
+}
+
+func (hint AssertAccessDeltasNonNegative) String() string {
+	return "AssertAccessDeltasNonNegative"
+}
+
+func (hint AssertAccessDeltasNonNegative) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	accessIndicesAddr, err := resolveAsAddress(vm, hint.accessIndices)
+	if err != nil {
+		return fmt.Errorf("resolve access indices operand %s: %v", hint.accessIndices, err)
+	}
+	length, err := resolveAsUint64(vm, hint.length)
+	if err != nil {
+		return fmt.Errorf("resolve length operand %s: %v", hint.length, err)
+	}
+
+	accessIndices, err := readFelts(vm, accessIndicesAddr, length)
+	if err != nil {
+		return err
+	}
+
+	for i := 1; i < len(accessIndices); i++ {
+		if accessIndices[i].Cmp(accessIndices[i-1]) < 0 {
+			return fmt.Errorf(
+				"negative delta at index %d: access index %s is smaller than the previous one %s",
+				i, accessIndices[i], accessIndices[i-1],
+			)
+		}
+	}
+	return nil
+}
+
+// Writes the Legendre symbol of a value with respect to the stark prime following
+// Euler's criterion: 1 for non-zero quadratic residues, -1 (i.e. p - 1) for
+// non-residues and 0 for zero
+type Legendre struct {
+	value ResOperander
+	dst   CellRefer
+}
+
+func (hint Legendre) String() string {
+	return "Legendre"
+}
+
+func (hint Legendre) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	value, err := resolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand %s: %v", hint.value, err)
+	}
+
+	symbol := f.Element{}
+	switch value.Legendre() {
+	case 1:
+		symbol.SetOne()
+	case -1:
+		symbol.SetOne()
+		symbol.Neg(&symbol)
+	}
+	return writeFelt(vm, hint.dst, &symbol)
+}
+
+// Compresses a stark curve point into its x coordinate and the parity bit of its y
+// coordinate. The x coordinate alone already needs the full felt bit size, so the
+// parity bit is written in its own cell
+type CompressEcPoint struct {
+	x         ResOperander
+	y         ResOperander
+	dstX      CellRefer
+	dstParity CellRefer
+}
+
+func (hint CompressEcPoint) String() string {
+	return "CompressEcPoint"
+}
+
+func (hint CompressEcPoint) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	x, err := resolveAsFelt(vm, hint.x)
+	if err != nil {
+		return fmt.Errorf("resolve x operand %s: %v", hint.x, err)
+	}
+	y, err := resolveAsFelt(vm, hint.y)
+	if err != nil {
+		return fmt.Errorf("resolve y operand %s: %v", hint.y, err)
+	}
+
+	point := starkcurve.G1Affine{X: *x, Y: *y}
+	if !point.IsOnCurve() {
+		return fmt.Errorf("point (%s, %s) is not on the curve", x, y)
+	}
+
+	if err := writeFelt(vm, hint.dstX, x); err != nil {
+		return err
+	}
+	parity := new(f.Element).SetUint64(uint64(y.BigInt(new(big.Int)).Bit(0)))
+	return writeFelt(vm, hint.dstParity, parity)
+}
+
+// Recovers the y coordinate of a stark curve point compressed with CompressEcPoint
+// by taking the square root of x^3 + a * x + b with the matching parity
+type DecompressEcPoint struct {
+	x      ResOperander
+	parity ResOperander
+	dstY   CellRefer
+}
+
+func (hint DecompressEcPoint) String() string {
+	return "DecompressEcPoint"
+}
+
+func (hint DecompressEcPoint) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	x, err := resolveAsFelt(vm, hint.x)
+	if err != nil {
+		return fmt.Errorf("resolve x operand %s: %v", hint.x, err)
+	}
+	parity, err := resolveAsUint64(vm, hint.parity)
+	if err != nil {
+		return fmt.Errorf("resolve parity operand %s: %v", hint.parity, err)
+	}
+	if parity > 1 {
+		return fmt.Errorf("parity %d should be either 0 or 1", parity)
+	}
+
+	point, ok := builtins.PointFromX(x)
+	if !ok {
+		return fmt.Errorf("x %s is not the coordinate of any point of the curve", x)
+	}
+	if uint64(point.Y.BigInt(new(big.Int)).Bit(0)) != parity {
+		point.Neg(&point)
+	}
+	return writeFelt(vm, hint.dstY, &point.Y)
+}
+
+// Asserts that no more than budget range check instances have been used so far,
+// i.e. that the range check segment holds at most budget cells
+type AssertRangeCheckBudget struct {
+	budget ResOperander
+}
+
+func (hint AssertRangeCheckBudget) String() string {
+	return "AssertRangeCheckBudget"
+}
+
+func (hint AssertRangeCheckBudget) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	budget, err := resolveAsUint64(vm, hint.budget)
+	if err != nil {
+		return fmt.Errorf("resolve budget operand %s: %v", hint.budget, err)
+	}
+
+	segment, ok := vm.Memory.FindSegmentWithBuiltin(builtins.RangeCheckName)
+	if !ok {
+		return nil
+	}
+	if segment.Len() > budget {
+		return fmt.Errorf(
+			"%d range check instances used, exceeding the budget of %d", segment.Len(), budget,
+		)
+	}
+	return nil
+}
+
+// Computes a rolling field checksum over a range of felts and writes it
+type Checksum struct {
+	start  ResOperander
+	length ResOperander
+	dst    CellRefer
+}
+
+func (hint Checksum) String() string {
+	return "Checksum"
+}
+
+func (hint Checksum) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	startAddr, err := resolveAsAddress(vm, hint.start)
+	if err != nil {
+		return fmt.Errorf("resolve start operand %s: %v", hint.start, err)
+	}
+	length, err := resolveAsUint64(vm, hint.length)
+	if err != nil {
+		return fmt.Errorf("resolve length operand %s: %v", hint.length, err)
+	}
+
+	values, err := readFelts(vm, startAddr, length)
+	if err != nil {
+		return err
+	}
+
+	checksum := rollingChecksum(values)
+	return writeFelt(vm, hint.dst, &checksum)
+}
+
+// Computes the same checksum as Checksum over a range of felts and asserts that it
+// matches the expected one
+type VerifyChecksum struct {
+	start    ResOperander
+	length   ResOperander
+	expected ResOperander
+}
+
+func (hint VerifyChecksum) String() string {
+	return "VerifyChecksum"
+}
+
+func (hint VerifyChecksum) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	startAddr, err := resolveAsAddress(vm, hint.start)
+	if err != nil {
+		return fmt.Errorf("resolve start operand %s: %v", hint.start, err)
+	}
+	length, err := resolveAsUint64(vm, hint.length)
+	if err != nil {
+		return fmt.Errorf("resolve length operand %s: %v", hint.length, err)
+	}
+	expected, err := resolveAsFelt(vm, hint.expected)
+	if err != nil {
+		return fmt.Errorf("resolve expected operand %s: %v", hint.expected, err)
+	}
+
+	values, err := readFelts(vm, startAddr, length)
+	if err != nil {
+		return err
+	}
+
+	checksum := rollingChecksum(values)
+	if !checksum.Equal(expected) {
+		return fmt.Errorf("checksum %s is different from the expected checksum %s", &checksum, expected)
+	}
+	return nil
+}
+
+// Allocates a rows x columns 2D array as a backing segment holding the cells row after
+// row, plus a segment with a pointer to the start of every row. The pointer to the
+// row pointers is written in dst
+type Alloc2DArray struct {
+	rows    ResOperander
+	columns ResOperander
+	dst     CellRefer
+}
+
+func (hint Alloc2DArray) String() string {
+	return "Alloc2DArray"
+}
+
+func (hint Alloc2DArray) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	rows, err := resolveAsUint64(vm, hint.rows)
+	if err != nil {
+		return fmt.Errorf("resolve rows operand %s: %v", hint.rows, err)
+	}
+	columns, err := resolveAsUint64(vm, hint.columns)
+	if err != nil {
+		return fmt.Errorf("resolve columns operand %s: %v", hint.columns, err)
+	}
+
+	backingSegment := vm.Memory.AllocateEmptySegment()
+	rowsSegment := vm.Memory.AllocateEmptySegment()
+	for i := uint64(0); i < rows; i++ {
+		rowPtr := memory.MemoryValueFromSegmentAndOffset(uint64(backingSegment), i*columns)
+		if err := vm.Memory.Write(uint64(rowsSegment), i, &rowPtr); err != nil {
+			return fmt.Errorf("write row pointer %d: %v", i, err)
+		}
+	}
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell %s: %w", hint.dst, err)
+	}
+	rowsPtr := memory.MemoryValueFromSegmentAndOffset(rowsSegment, 0)
+	if err := vm.Memory.WriteToAddress(&dstAddr, &rowsPtr); err != nil {
+		return fmt.Errorf("write to destination cell %s: %w", dstAddr, err)
+	}
+	return nil
+}
+
+// Given the row pointers of a rows x columns matrix, writes in a new segment the pointers
+// to every matrix element in column-major order, i.e. the row-major layout of the
+// transposed matrix. The pointer to element (i, j) of the original matrix ends up at
+// offset j * rows + i. The pointer to the new segment is written in dst
+type TransposeLayout struct {
+	rowPtrs ResOperander
+	rows    ResOperander
+	columns ResOperander
+	dst     CellRefer
+}
+
+func (hint TransposeLayout) String() string {
+	return "TransposeLayout"
+}
+
+func (hint TransposeLayout) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	rowPtrsAddr, err := resolveAsAddress(vm, hint.rowPtrs)
+	if err != nil {
+		return fmt.Errorf("resolve row pointers operand %s: %v", hint.rowPtrs, err)
+	}
+	rows, err := resolveAsUint64(vm, hint.rows)
+	if err != nil {
+		return fmt.Errorf("resolve rows operand %s: %v", hint.rows, err)
+	}
+	columns, err := resolveAsUint64(vm, hint.columns)
+	if err != nil {
+		return fmt.Errorf("resolve columns operand %s: %v", hint.columns, err)
+	}
+
+	transposedSegment := uint64(vm.Memory.AllocateEmptySegment())
+	for i := uint64(0); i < rows; i++ {
+		rowPtr, err := vm.Memory.Read(rowPtrsAddr.SegmentIndex, rowPtrsAddr.Offset+i)
+		if err != nil {
+			return fmt.Errorf("read row pointer %d: %v", i, err)
+		}
+		rowAddr, err := rowPtr.MemoryAddress()
+		if err != nil {
+			return fmt.Errorf("read row pointer %d: %v", i, err)
+		}
+
+		for j := uint64(0); j < columns; j++ {
+			elementPtr := memory.MemoryValueFromSegmentAndOffset(
+				rowAddr.SegmentIndex, rowAddr.Offset+j,
+			)
+			if err := vm.Memory.Write(transposedSegment, j*rows+i, &elementPtr); err != nil {
+				return fmt.Errorf("write pointer to element (%d, %d): %v", i, j, err)
+			}
+		}
+	}
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell %s: %w", hint.dst, err)
+	}
+	transposedPtr := memory.MemoryValueFromSegmentAndOffset(transposedSegment, 0)
+	if err := vm.Memory.WriteToAddress(&dstAddr, &transposedPtr); err != nil {
+		return fmt.Errorf("write to destination cell %s: %w", dstAddr, err)
+	}
+	return nil
+}
+
+// Fills a range with the recurrence f[i] = f[i - 1] + f[i - 2] computed in the field,
+// starting from the two given seeds. Lengths smaller than two only write the
+// corresponding seeds
+type FillRecurrence struct {
+	start  ResOperander
+	length ResOperander
+	first  ResOperander
+	second ResOperander
+}
+
+func (hint FillRecurrence) String() string {
+	return "FillRecurrence"
+}
+
+func (hint FillRecurrence) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	startAddr, err := resolveAsAddress(vm, hint.start)
+	if err != nil {
+		return fmt.Errorf("resolve start operand %s: %v", hint.start, err)
+	}
+	length, err := resolveAsUint64(vm, hint.length)
+	if err != nil {
+		return fmt.Errorf("resolve length operand %s: %v", hint.length, err)
+	}
+	first, err := resolveAsFelt(vm, hint.first)
+	if err != nil {
+		return fmt.Errorf("resolve first operand %s: %v", hint.first, err)
+	}
+	second, err := resolveAsFelt(vm, hint.second)
+	if err != nil {
+		return fmt.Errorf("resolve second operand %s: %v", hint.second, err)
+	}
+
+	previous, current := *first, *second
+	for i := uint64(0); i < length; i++ {
+		var value f.Element
+		switch i {
+		case 0:
+			value = *first
+		case 1:
+			value = *second
+		default:
+			value.Add(&previous, &current)
+			previous, current = current, value
+		}
+
+		mv := memory.MemoryValueFromFieldElement(&value)
+		if err := vm.Memory.Write(startAddr.SegmentIndex, startAddr.Offset+i, &mv); err != nil {
+			return fmt.Errorf("write element %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// Writes the multiplicative inverse of a value over the stark field. Errors on zero,
+// since it has no inverse
+type Inverse struct {
+	value ResOperander
+	dst   CellRefer
+}
+
+func (hint Inverse) String() string {
+	return "Inverse"
+}
+
+func (hint Inverse) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	value, err := resolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand %s: %v", hint.value, err)
+	}
+	if value.IsZero() {
+		return fmt.Errorf("cannot invert zero")
+	}
+
+	inverse := f.Element{}
+	inverse.Inverse(value)
+	return writeFelt(vm, hint.dst, &inverse)
+}
+
+// Reads the value at [[ptr] + offset] and writes it to dst. Errors if [ptr] is not a
+// pointer or if nothing has been written at the final cell
+type ReadDoubleDeref struct {
+	ptr    CellRefer
+	offset int16
+	dst    CellRefer
+}
+
+func (hint ReadDoubleDeref) String() string {
+	return "ReadDoubleDeref"
+}
+
+func (hint ReadDoubleDeref) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	operand := DoubleDeref{deref: hint.ptr, offset: hint.offset}
+	value, err := operand.Resolve(vm)
+	if err != nil {
+		return fmt.Errorf("resolve [[%s] + %d]: %v", hint.ptr, hint.offset, err)
+	}
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell %s: %w", hint.dst, err)
+	}
+	if err := vm.Memory.WriteToAddress(&dstAddr, &value); err != nil {
+		return fmt.Errorf("write to destination cell %s: %w", dstAddr, err)
+	}
+	return nil
+}
+
+// Asserts that every cell of a range equals the given constant
+type AssertAllEq struct {
+	start    ResOperander
+	length   ResOperander
+	constant ResOperander
+}
+
+func (hint AssertAllEq) String() string {
+	return "AssertAllEq"
+}
+
+func (hint AssertAllEq) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	startAddr, err := resolveAsAddress(vm, hint.start)
+	if err != nil {
+		return fmt.Errorf("resolve start operand %s: %v", hint.start, err)
+	}
+	length, err := resolveAsUint64(vm, hint.length)
+	if err != nil {
+		return fmt.Errorf("resolve length operand %s: %v", hint.length, err)
+	}
+	constant, err := resolveAsFelt(vm, hint.constant)
+	if err != nil {
+		return fmt.Errorf("resolve constant operand %s: %v", hint.constant, err)
+	}
+
+	values, err := readFelts(vm, startAddr, length)
+	if err != nil {
+		return err
+	}
+
+	for i, value := range values {
+		if !value.Equal(constant) {
+			return fmt.Errorf("value %s at index %d is different from %s", value, i, constant)
+		}
+	}
+	return nil
+}
+
+// Writes the prefix maxima of a source range into a destination range, interpreting
+// the values as field integers, so that dst[i] = max(src[0], ..., src[i])
+type RunningMax struct {
+	src    ResOperander
+	dst    ResOperander
+	length ResOperander
+}
+
+func (hint RunningMax) String() string {
+	return "RunningMax"
+}
+
+func (hint RunningMax) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	srcAddr, err := resolveAsAddress(vm, hint.src)
+	if err != nil {
+		return fmt.Errorf("resolve src operand %s: %v", hint.src, err)
+	}
+	dstAddr, err := resolveAsAddress(vm, hint.dst)
+	if err != nil {
+		return fmt.Errorf("resolve dst operand %s: %v", hint.dst, err)
+	}
+	length, err := resolveAsUint64(vm, hint.length)
+	if err != nil {
+		return fmt.Errorf("resolve length operand %s: %v", hint.length, err)
+	}
+
+	values, err := readFelts(vm, srcAddr, length)
+	if err != nil {
+		return err
+	}
+
+	max := f.Element{}
+	for i, value := range values {
+		if i == 0 || value.Cmp(&max) > 0 {
+			max = *value
+		}
+		mv := memory.MemoryValueFromFieldElement(&max)
+		if err := vm.Memory.Write(dstAddr.SegmentIndex, dstAddr.Offset+uint64(i), &mv); err != nil {
+			return fmt.Errorf("write maximum %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// Asserts that a value is a valid Ethereum address, i.e. that it fits in 160 bits
+type AssertEthAddress struct {
+	value ResOperander
+}
+
+func (hint AssertEthAddress) String() string {
+	return "AssertEthAddress"
+}
+
+func (hint AssertEthAddress) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	value, err := resolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand %s: %v", hint.value, err)
+	}
+
+	if value.BigInt(new(big.Int)).Cmp(ethAddressBound) >= 0 {
+		return fmt.Errorf("value %s is not a valid Ethereum address", value)
+	}
+	return nil
+}
+
+// Computes the keccak256 hash of a sequence of u256 words packed as big-endian 32 bytes
+// words, matching Solidity's `keccak256(abi.encodePacked(...))`. Each word is read as
+// its low and high 128 bits limbs, in that order, and the hash is written the same way
+type KeccakPackedUint256 struct {
+	words  ResOperander
+	length ResOperander
+	low    CellRefer
+	high   CellRefer
+}
+
+func (hint KeccakPackedUint256) String() string {
+	return "KeccakPackedUint256"
+}
+
+func (hint KeccakPackedUint256) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	wordsAddr, err := resolveAsAddress(vm, hint.words)
+	if err != nil {
+		return fmt.Errorf("resolve words operand %s: %v", hint.words, err)
+	}
+	length, err := resolveAsUint64(vm, hint.length)
+	if err != nil {
+		return fmt.Errorf("resolve length operand %s: %v", hint.length, err)
+	}
+
+	limbs, err := readFelts(vm, wordsAddr, 2*length)
+	if err != nil {
+		return err
+	}
+
+	hasher := sha3.NewLegacyKeccak256()
+	for i := uint64(0); i < length; i++ {
+		low := limbs[2*i].BigInt(new(big.Int))
+		high := limbs[2*i+1].BigInt(new(big.Int))
+		if low.BitLen() > 128 {
+			return fmt.Errorf("word %d: low limb %s should be u128", i, low)
+		}
+		if high.BitLen() > 128 {
+			return fmt.Errorf("word %d: high limb %s should be u128", i, high)
+		}
+
+		var word [32]byte
+		high.Lsh(high, 128).Or(high, low).FillBytes(word[:])
+		hasher.Write(word[:])
+	}
+
+	hash := new(big.Int).SetBytes(hasher.Sum(nil))
+	return writeUint256(vm, hint.low, hint.high, hash)
+}
+
+// Asserts that two operands are different. Field elements are compared in the field,
+// so values congruent modulo the stark prime are considered equal
+type AssertNeq struct {
+	lhs ResOperander
+	rhs ResOperander
+}
+
+func (hint AssertNeq) String() string {
+	return "AssertNeq"
+}
+
+func (hint AssertNeq) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	lhs, err := hint.lhs.Resolve(vm)
+	if err != nil {
+		return fmt.Errorf("resolve lhs operand %s: %v", hint.lhs, err)
+	}
+	rhs, err := hint.rhs.Resolve(vm)
+	if err != nil {
+		return fmt.Errorf("resolve rhs operand %s: %v", hint.rhs, err)
+	}
+
+	if lhs.Equal(&rhs) {
+		return fmt.Errorf("lhs %s and rhs %s are equal", &lhs, &rhs)
+	}
+	return nil
+}
+
+// Writes the two-adic valuation of a value, the largest k such that 2**k divides it.
+// Since every power of two divides zero, zero yields the felt bit size
+type TwoAdicValuation struct {
+	value ResOperander
+	dst   CellRefer
+}
+
+func (hint TwoAdicValuation) String() string {
+	return "TwoAdicValuation"
+}
+
+// The valuation is the number of trailing zero bits, zero included
+func (hint TwoAdicValuation) Execute(vm *VM.VirtualMachine, ctx *HintRunnerContext) error {
+	return TrailingZeros{value: hint.value, dst: hint.dst}.Execute(vm, ctx)
+}
+
+// Asserts that the square matrix of the given dimension, given by its row pointers,
+// is the identity matrix
+type AssertIdentityMatrix struct {
+	rowPtrs   ResOperander
+	dimension ResOperander
+}
+
+func (hint AssertIdentityMatrix) String() string {
+	return "AssertIdentityMatrix"
+}
+
+func (hint AssertIdentityMatrix) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	rowPtrsAddr, err := resolveAsAddress(vm, hint.rowPtrs)
+	if err != nil {
+		return fmt.Errorf("resolve row pointers operand %s: %v", hint.rowPtrs, err)
+	}
+	dimension, err := resolveAsUint64(vm, hint.dimension)
+	if err != nil {
+		return fmt.Errorf("resolve dimension operand %s: %v", hint.dimension, err)
+	}
+
+	for i := uint64(0); i < dimension; i++ {
+		rowPtr, err := vm.Memory.Read(rowPtrsAddr.SegmentIndex, rowPtrsAddr.Offset+i)
+		if err != nil {
+			return fmt.Errorf("read row pointer %d: %v", i, err)
+		}
+		rowAddr, err := rowPtr.MemoryAddress()
+		if err != nil {
+			return fmt.Errorf("read row pointer %d: %v", i, err)
+		}
+
+		row, err := readFelts(vm, rowAddr, dimension)
+		if err != nil {
+			return fmt.Errorf("read row %d: %v", i, err)
+		}
+		for j, value := range row {
+			if uint64(j) == i && !value.IsOne() {
+				return fmt.Errorf("diagonal element (%d, %d) is %s instead of 1", i, j, value)
+			}
+			if uint64(j) != i && !value.IsZero() {
+				return fmt.Errorf("element (%d, %d) is %s instead of 0", i, j, value)
+			}
+		}
+	}
+	return nil
+}
+
+// Writes the prefix sums of a source range into a destination range, computed in the
+// field, so that dst[i] = src[0] + ... + src[i]
+type PrefixSum struct {
+	src    ResOperander
+	dst    ResOperander
+	length ResOperander
+}
+
+func (hint PrefixSum) String() string {
+	return "PrefixSum"
+}
+
+func (hint PrefixSum) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	srcAddr, err := resolveAsAddress(vm, hint.src)
+	if err != nil {
+		return fmt.Errorf("resolve src operand %s: %v", hint.src, err)
+	}
+	dstAddr, err := resolveAsAddress(vm, hint.dst)
+	if err != nil {
+		return fmt.Errorf("resolve dst operand %s: %v", hint.dst, err)
+	}
+	length, err := resolveAsUint64(vm, hint.length)
+	if err != nil {
+		return fmt.Errorf("resolve length operand %s: %v", hint.length, err)
+	}
+
+	values, err := readFelts(vm, srcAddr, length)
+	if err != nil {
+		return err
+	}
+
+	sum := f.Element{}
+	for i, value := range values {
+		sum.Add(&sum, value)
+		mv := memory.MemoryValueFromFieldElement(&sum)
+		if err := vm.Memory.Write(dstAddr.SegmentIndex, dstAddr.Offset+uint64(i), &mv); err != nil {
+			return fmt.Errorf("write sum %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// Asserts that the squashed state of the dictionary a pointer belongs to matches the
+// expected one, catching the use of a dictionary after it was squashed, and the use
+// of a dictionary as squashed before it was
+type AssertDictSquashed struct {
+	dictPtr  ResOperander
+	squashed bool
+}
+
+func (hint AssertDictSquashed) String() string {
+	return "AssertDictSquashed"
+}
+
+func (hint AssertDictSquashed) Execute(vm *VM.VirtualMachine, ctx *HintRunnerContext) error {
+	dictPtr, err := resolveAsAddress(vm, hint.dictPtr)
+	if err != nil {
+		return fmt.Errorf("resolve dict ptr operand %s: %v", hint.dictPtr, err)
+	}
+
+	dict, err := ctx.DictionaryManager.GetDictionary(dictPtr)
+	if err != nil {
+		return err
+	}
+
+	if dict.Squashed() != hint.squashed {
+		if dict.Squashed() {
+			return fmt.Errorf("dictionary at %s is used after being squashed", dictPtr)
+		}
+		return fmt.Errorf("dictionary at %s is used before being squashed", dictPtr)
+	}
+	return nil
+}
+
+// Computes the euclidean division of two u128 operands, writing the quotient and
+// the remainder to their destination cells
+type DivMod struct {
+	lhs       ResOperander
+	rhs       ResOperander
+	quotient  CellRefer
+	remainder CellRefer
+}
+
+func (hint DivMod) String() string {
+	return "DivMod"
+}
+
+func (hint DivMod) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	mask := MaxU128()
+
+	lhsFelt, err := resolveAsFelt(vm, hint.lhs)
+	if err != nil {
+		return fmt.Errorf("resolve lhs operand %s: %w", hint.lhs, err)
+	}
+	rhsFelt, err := resolveAsFelt(vm, hint.rhs)
+	if err != nil {
+		return fmt.Errorf("resolve rhs operand %s: %w", hint.rhs, err)
+	}
+
+	lhsU256 := uint256.Int(lhsFelt.Bits())
+	rhsU256 := uint256.Int(rhsFelt.Bits())
+
+	if lhsU256.Gt(&mask) {
+		return fmt.Errorf("lhs operand %s should be u128", lhsFelt)
+	}
+	if rhsU256.Gt(&mask) {
+		return fmt.Errorf("rhs operand %s should be u128", rhsFelt)
+	}
+	if rhsU256.IsZero() {
+		return fmt.Errorf("cannot divide by zero")
+	}
+
+	quotientBig, remainderBig := new(big.Int).DivMod(lhsU256.ToBig(), rhsU256.ToBig(), new(big.Int))
+
+	quotient := f.Element{}
+	quotient.SetBigInt(quotientBig)
+	if err := writeFelt(vm, hint.quotient, &quotient); err != nil {
+		return fmt.Errorf("write quotient: %w", err)
+	}
+
+	remainder := f.Element{}
+	remainder.SetBigInt(remainderBig)
+	if err := writeFelt(vm, hint.remainder, &remainder); err != nil {
+		return fmt.Errorf("write remainder: %w", err)
+	}
+	return nil
+}
+
+// Reduces a range of felts into a single value by XOR-ing all of them together, the
+// result is taken modulo the stark prime. The empty range yields 0
+type XorFold struct {
+	start  ResOperander
+	length ResOperander
+	dst    CellRefer
+}
+
+func (hint XorFold) String() string {
+	return "XorFold"
+}
+
+func (hint XorFold) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	startAddr, err := resolveAsAddress(vm, hint.start)
+	if err != nil {
+		return fmt.Errorf("resolve start operand %s: %v", hint.start, err)
+	}
+	length, err := resolveAsUint64(vm, hint.length)
+	if err != nil {
+		return fmt.Errorf("resolve length operand %s: %v", hint.length, err)
+	}
+
+	values, err := readFelts(vm, startAddr, length)
+	if err != nil {
+		return err
+	}
+
+	// the xor of two felts may exceed the prime, in which case it gets reduced
+	result := new(big.Int)
+	for _, value := range values {
+		result.Xor(result, value.BigInt(new(big.Int)))
+	}
+
+	resultFelt := f.Element{}
+	resultFelt.SetBigInt(result)
+	return writeFelt(vm, hint.dst, &resultFelt)
+}
+
+// Adds two u256 values given as their low and high 128 bits limbs, writing the limbs
+// of the sum modulo 2**256 and a carry cell set to 1 when the addition overflows
+// 256 bits, 0 otherwise
+type Uint256Add struct {
+	aLow  ResOperander
+	aHigh ResOperander
+	bLow  ResOperander
+	bHigh ResOperander
+	low   CellRefer
+	high  CellRefer
+	carry CellRefer
+}
+
+func (hint Uint256Add) String() string {
+	return "Uint256Add"
+}
+
+func (hint Uint256Add) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	mask := MaxU128()
+
+	limbs := [4]uint256.Int{}
+	for i, operand := range []ResOperander{hint.aLow, hint.aHigh, hint.bLow, hint.bHigh} {
+		felt, err := resolveAsFelt(vm, operand)
+		if err != nil {
+			return fmt.Errorf("resolve operand %s: %v", operand, err)
+		}
+		limbs[i] = uint256.Int(felt.Bits())
+		if limbs[i].Gt(&mask) {
+			return fmt.Errorf("operand %s should be u128", felt)
+		}
+	}
+
+	a := new(uint256.Int).Lsh(&limbs[1], 128)
+	a.Or(a, &limbs[0])
+	b := new(uint256.Int).Lsh(&limbs[3], 128)
+	b.Or(b, &limbs[2])
+
+	sum, overflow := new(uint256.Int).AddOverflow(a, b)
+
+	bytes := sum.Bytes32()
+
+	low := f.Element{}
+	low.SetBytes(bytes[16:])
+	if err := writeFelt(vm, hint.low, &low); err != nil {
+		return err
+	}
+
+	high := f.Element{}
+	high.SetBytes(bytes[:16])
+	if err := writeFelt(vm, hint.high, &high); err != nil {
+		return err
+	}
+
+	carry := f.Element{}
+	if overflow {
+		carry.SetOne()
+	}
+	return writeFelt(vm, hint.carry, &carry)
+}
+
+// Asserts that a value equals the Poseidon hash of two inputs
+type AssertPoseidonEq struct {
+	x        ResOperander
+	y        ResOperander
+	expected ResOperander
+}
+
+func (hint AssertPoseidonEq) String() string {
+	return "AssertPoseidonEq"
+}
+
+func (hint AssertPoseidonEq) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	x, err := resolveAsFelt(vm, hint.x)
+	if err != nil {
+		return fmt.Errorf("resolve x operand %s: %v", hint.x, err)
+	}
+	y, err := resolveAsFelt(vm, hint.y)
+	if err != nil {
+		return fmt.Errorf("resolve y operand %s: %v", hint.y, err)
+	}
+	expected, err := resolveAsFelt(vm, hint.expected)
+	if err != nil {
+		return fmt.Errorf("resolve expected operand %s: %v", hint.expected, err)
+	}
+
+	hash := builtins.PoseidonHash(x, y)
+	if !hash.Equal(expected) {
+		return fmt.Errorf("poseidon hash %s is different from the expected value %s", &hash, expected)
+	}
+	return nil
+}
+
+// Computes the integer square root of an u256 value given as its low and high 128 bits
+// limbs, following the cell layout of the Cairo core library `u256_sqrt`: the root is
+// written as its low (sqrt0) and high (sqrt1) 64 bits limbs, the remainder
+// value - root^2 as its low and high 128 bits limbs, and a flag set to 1 when
+// 2 * root - remainder is at least 2**128
+type Uint256SquareRoot struct {
+	valueLow                     ResOperander
+	valueHigh                    ResOperander
+	sqrt0                        CellRefer
+	sqrt1                        CellRefer
+	remainderLow                 CellRefer
+	remainderHigh                CellRefer
+	sqrtMul2MinusRemainderGeU128 CellRefer
+}
+
+func (hint Uint256SquareRoot) String() string {
+	return "Uint256SquareRoot"
+}
+
+func (hint Uint256SquareRoot) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	value, err := resolveAsUint256(vm, hint.valueLow, hint.valueHigh)
+	if err != nil {
+		return err
+	}
+
+	root := new(big.Int).Sqrt(value)
+	remainder := new(big.Int).Mul(root, root)
+	remainder.Sub(value, remainder)
+
+	mask64 := new(big.Int).Lsh(big.NewInt(1), 64)
+	mask64.Sub(mask64, big.NewInt(1))
+
+	sqrt0 := f.Element{}
+	sqrt0.SetBigInt(new(big.Int).And(root, mask64))
+	if err := writeFelt(vm, hint.sqrt0, &sqrt0); err != nil {
+		return err
+	}
+	sqrt1 := f.Element{}
+	sqrt1.SetBigInt(new(big.Int).Rsh(root, 64))
+	if err := writeFelt(vm, hint.sqrt1, &sqrt1); err != nil {
+		return err
+	}
+
+	if err := writeUint256(vm, hint.remainderLow, hint.remainderHigh, remainder); err != nil {
+		return err
+	}
+
+	// the remainder is never larger than 2 * root
+	flag := f.Element{}
+	diff := new(big.Int).Lsh(root, 1)
+	diff.Sub(diff, remainder)
+	if diff.BitLen() > 128 {
+		flag.SetOne()
+	}
+	return writeFelt(vm, hint.sqrtMul2MinusRemainderGeU128, &flag)
+}
+
+// Writes each value of a source range reduced by a shared modulus into a destination
+// range, so that dst[i] = src[i] % modulus. Values are taken as their canonical
+// representatives in [0, p)
+type BatchModReduce struct {
+	src     ResOperander
+	dst     ResOperander
+	length  ResOperander
+	modulus ResOperander
+}
+
+func (hint BatchModReduce) String() string {
+	return "BatchModReduce"
+}
+
+func (hint BatchModReduce) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	srcAddr, err := resolveAsAddress(vm, hint.src)
+	if err != nil {
+		return fmt.Errorf("resolve src operand %s: %v", hint.src, err)
+	}
+	dstAddr, err := resolveAsAddress(vm, hint.dst)
+	if err != nil {
+		return fmt.Errorf("resolve dst operand %s: %v", hint.dst, err)
+	}
+	length, err := resolveAsUint64(vm, hint.length)
+	if err != nil {
+		return fmt.Errorf("resolve length operand %s: %v", hint.length, err)
+	}
+	modulus, err := resolveAsFelt(vm, hint.modulus)
+	if err != nil {
+		return fmt.Errorf("resolve modulus operand %s: %v", hint.modulus, err)
+	}
+	if modulus.IsZero() {
+		return fmt.Errorf("cannot divide by zero")
+	}
+
+	values, err := readFelts(vm, srcAddr, length)
+	if err != nil {
+		return err
+	}
+
+	modulusBig := modulus.BigInt(new(big.Int))
+	remainderBig := new(big.Int)
+	remainder := f.Element{}
+	for i, value := range values {
+		value.BigInt(remainderBig)
+		remainderBig.Mod(remainderBig, modulusBig)
+		remainder.SetBigInt(remainderBig)
+
+		mv := memory.MemoryValueFromFieldElement(&remainder)
+		if err := vm.Memory.Write(dstAddr.SegmentIndex, dstAddr.Offset+uint64(i), &mv); err != nil {
+			return fmt.Errorf("write remainder %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// First hint of `assert_le_felt`: splits the field into the arcs [0, a], [a, b] and
+// [b, p - 1], takes the two shortest ones and writes them to the range check segment
+// as (low, high) pairs, divided by PRIME_OVER_3_HIGH and PRIME_OVER_2_HIGH. The index
+// of the longest arc, the one the proof excludes, is stored in the scope
+type AssertLeFindSmallArcs struct {
+	rangeCheckPtr ResOperander
+	a             ResOperander
+	b             ResOperander
+}
+
+func (hint AssertLeFindSmallArcs) String() string {
+	return "AssertLeFindSmallArcs"
+}
+
+func (hint AssertLeFindSmallArcs) Execute(vm *VM.VirtualMachine, ctx *HintRunnerContext) error {
+	rangeCheckPtr, err := resolveAsAddress(vm, hint.rangeCheckPtr)
+	if err != nil {
+		return fmt.Errorf("resolve range check pointer %s: %v", hint.rangeCheckPtr, err)
+	}
+	aFelt, err := resolveAsFelt(vm, hint.a)
+	if err != nil {
+		return fmt.Errorf("resolve a operand %s: %v", hint.a, err)
+	}
+	bFelt, err := resolveAsFelt(vm, hint.b)
+	if err != nil {
+		return fmt.Errorf("resolve b operand %s: %v", hint.b, err)
+	}
+
+	a := aFelt.BigInt(new(big.Int))
+	b := bFelt.BigInt(new(big.Int))
+	if a.Cmp(b) > 0 {
+		return fmt.Errorf("a = %s is not less than or equal to b = %s", aFelt, bFelt)
+	}
+
+	type arc struct {
+		length *big.Int
+		index  int
+	}
+	primeMinusOne := new(big.Int).Sub(f.Modulus(), big.NewInt(1))
+	arcs := []arc{
+		{a, 0},
+		{new(big.Int).Sub(b, a), 1},
+		{new(big.Int).Sub(primeMinusOne, b), 2},
+	}
+	sort.Slice(arcs, func(i, j int) bool {
+		if cmp := arcs[i].length.Cmp(arcs[j].length); cmp != 0 {
+			return cmp < 0
+		}
+		return arcs[i].index < arcs[j].index
+	})
+
+	primeOver3 := new(big.Int).Div(f.Modulus(), big.NewInt(3))
+	primeOver2 := new(big.Int).Div(f.Modulus(), big.NewInt(2))
+	if arcs[0].length.Cmp(primeOver3) > 0 || arcs[1].length.Cmp(primeOver2) > 0 {
+		return fmt.Errorf("arcs %s and %s are too long", arcs[0].length, arcs[1].length)
+	}
+	ctx.SetVariable("excluded", arcs[2].index)
+
+	for i, divisor := range []*big.Int{primeOver3High, primeOver2High} {
+		quotient, remainder := new(big.Int).DivMod(arcs[i].length, divisor, new(big.Int))
+		for j, value := range []*big.Int{remainder, quotient} {
+			felt := f.Element{}
+			felt.SetBigInt(value)
+			mv := memory.MemoryValueFromFieldElement(&felt)
+			offset := rangeCheckPtr.Offset + uint64(2*i+j)
+			if err := vm.Memory.Write(rangeCheckPtr.SegmentIndex, offset, &mv); err != nil {
+				return fmt.Errorf("write range check value %d: %v", 2*i+j, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Writes 1 to skipExcludeAFlag if the excluded arc is not [0, a], and 0 otherwise
+type AssertLeIsFirstArcExcluded struct {
+	skipExcludeAFlag CellRefer
+}
+
+func (hint AssertLeIsFirstArcExcluded) String() string {
+	return "AssertLeIsFirstArcExcluded"
+}
+
+func (hint AssertLeIsFirstArcExcluded) Execute(vm *VM.VirtualMachine, ctx *HintRunnerContext) error {
+	excluded, err := getVariableAs[int](ctx, "excluded")
+	if err != nil {
+		return err
+	}
+
+	flag := f.Element{}
+	if excluded != 0 {
+		flag.SetOne()
+	}
+	return writeFelt(vm, hint.skipExcludeAFlag, &flag)
+}
+
+// Writes 1 to skipExcludeBMinusA if the excluded arc is not [a, b], and 0 otherwise
+type AssertLeIsSecondArcExcluded struct {
+	skipExcludeBMinusA CellRefer
+}
+
+func (hint AssertLeIsSecondArcExcluded) String() string {
+	return "AssertLeIsSecondArcExcluded"
+}
+
+func (hint AssertLeIsSecondArcExcluded) Execute(vm *VM.VirtualMachine, ctx *HintRunnerContext) error {
+	excluded, err := getVariableAs[int](ctx, "excluded")
+	if err != nil {
+		return err
+	}
+
+	flag := f.Element{}
+	if excluded != 1 {
+		flag.SetOne()
+	}
+	return writeFelt(vm, hint.skipExcludeBMinusA, &flag)
+}
+
+// Records the current ap in the context, to be checked later by AssertApDelta
+type RecordAp struct{}
+
+func (hint RecordAp) String() string {
+	return "RecordAp"
+}
+
+func (hint RecordAp) Execute(vm *VM.VirtualMachine, ctx *HintRunnerContext) error {
+	ap := vm.Context.Ap
+	ctx.previousAp = &ap
+	return nil
+}
+
+// Asserts that ap has advanced by exactly delta cells since it was last recorded,
+// then records the current ap so consecutive frames can be checked one after the other
+type AssertApDelta struct {
+	delta uint64
+}
+
+func (hint AssertApDelta) String() string {
+	return "AssertApDelta"
+}
+
+func (hint AssertApDelta) Execute(vm *VM.VirtualMachine, ctx *HintRunnerContext) error {
+	if ctx.previousAp == nil {
+		return fmt.Errorf("no previous ap recorded")
+	}
+
+	previousAp := *ctx.previousAp
+	ap := vm.Context.Ap
+	if ap < previousAp || ap-previousAp != hint.delta {
+		return fmt.Errorf(
+			"ap %d should have advanced by %d from the previous ap %d", ap, hint.delta, previousAp,
+		)
+	}
+	ctx.previousAp = &ap
+	return nil
+}
+
+// Writes the final state of a squashed dictionary into a destination range as
+// consecutive DictAccess triples (key, prev_value, new_value) sorted by key, where
+// prev_value is the value before the first access to the key and new_value the one
+// after its last access. The accesses are read from the start of the dictionary
+// segment up to dictEnd
+type SerializeSquashedDict struct {
+	dictEnd ResOperander
+	dst     ResOperander
+}
+
+func (hint SerializeSquashedDict) String() string {
+	return "SerializeSquashedDict"
+}
+
+func (hint SerializeSquashedDict) Execute(vm *VM.VirtualMachine, ctx *HintRunnerContext) error {
+	dictEnd, err := resolveAsAddress(vm, hint.dictEnd)
+	if err != nil {
+		return fmt.Errorf("resolve dict end operand %s: %v", hint.dictEnd, err)
+	}
+	dstAddr, err := resolveAsAddress(vm, hint.dst)
+	if err != nil {
+		return fmt.Errorf("resolve dst operand %s: %v", hint.dst, err)
+	}
+
+	dict, err := ctx.DictionaryManager.GetDictionary(dictEnd)
+	if err != nil {
+		return err
+	}
+	if !dict.Squashed() {
+		return fmt.Errorf("dictionary at %s is used before being squashed", dictEnd)
+	}
+	if dictEnd.Offset%3 != 0 {
+		return fmt.Errorf("dictionary end offset %d is not a multiple of the access size", dictEnd.Offset)
+	}
+
+	// index in the output of every key, and the triples themselves
+	type access struct {
+		key       f.Element
+		prevValue memory.MemoryValue
+		newValue  memory.MemoryValue
+	}
+	indices := make(map[f.Element]int)
+	accesses := []access{}
+	for offset := uint64(0); offset < dictEnd.Offset; offset += 3 {
+		cells := [3]memory.MemoryValue{}
+		for i := range cells {
+			cells[i], err = vm.Memory.Read(dictEnd.SegmentIndex, offset+uint64(i))
+			if err != nil {
+				return fmt.Errorf("read access %d: %v", offset/3, err)
+			}
+		}
+		key, err := cells[0].FieldElement()
+		if err != nil {
+			return fmt.Errorf("read key of access %d: %v", offset/3, err)
+		}
+
+		if index, ok := indices[*key]; ok {
+			accesses[index].newValue = cells[2]
+			continue
+		}
+		indices[*key] = len(accesses)
+		accesses = append(accesses, access{*key, cells[1], cells[2]})
+	}
+
+	sort.Slice(accesses, func(i, j int) bool {
+		return accesses[i].key.Cmp(&accesses[j].key) < 0
+	})
+
+	for i := range accesses {
+		cells := []memory.MemoryValue{
+			memory.MemoryValueFromFieldElement(&accesses[i].key),
+			accesses[i].prevValue,
+			accesses[i].newValue,
+		}
+		for j := range cells {
+			offset := dstAddr.Offset + uint64(3*i+j)
+			if err := vm.Memory.Write(dstAddr.SegmentIndex, offset, &cells[j]); err != nil {
+				return fmt.Errorf("write access %d: %v", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Creates a new Felt252 dictionary, whose keys default to zero, and writes the address
+// where its accesses start to dst
+type Felt252DictNew struct {
+	dst CellRefer
+}
+
+func (hint Felt252DictNew) String() string {
+	return "Felt252DictNew"
+}
+
+func (hint Felt252DictNew) Execute(vm *VM.VirtualMachine, ctx *HintRunnerContext) error {
+	dictAddr := ctx.DictionaryManager.NewDictionary(vm, memory.MemoryValueFromFieldElement(&f.Element{}))
+	if err := writeAddress(vm, hint.dst, &dictAddr); err != nil {
+		return fmt.Errorf("write dictionary address: %w", err)
+	}
+	return nil
+}
+
+// Computes (a * b) % modulus for u384 values given as four 96 bits limbs each, from
+// the least significant one, and writes the result limbs
+type Uint384MulMod struct {
+	a       [4]ResOperander
+	b       [4]ResOperander
+	modulus [4]ResOperander
+	result  [4]CellRefer
+}
+
+func (hint Uint384MulMod) String() string {
+	return "Uint384MulMod"
+}
+
+func (hint Uint384MulMod) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	a, err := resolveAsUint384(vm, hint.a)
+	if err != nil {
+		return fmt.Errorf("resolve a operand: %v", err)
+	}
+	b, err := resolveAsUint384(vm, hint.b)
+	if err != nil {
+		return fmt.Errorf("resolve b operand: %v", err)
+	}
+	modulus, err := resolveAsUint384(vm, hint.modulus)
+	if err != nil {
+		return fmt.Errorf("resolve modulus operand: %v", err)
+	}
+	if modulus.Sign() == 0 {
+		return fmt.Errorf("cannot divide by zero")
+	}
+
+	result := new(big.Int).Mul(a, b)
+	result.Mod(result, modulus)
+	return writeUint384(vm, hint.result, result)
+}
+
+// Writes the current value of a key in the dictionary a pointer belongs to, or the
+// dictionary default value if the key was never written, to valueDst
+type Felt252DictRead struct {
+	dictPtr  ResOperander
+	key      ResOperander
+	valueDst CellRefer
+}
+
+func (hint Felt252DictRead) String() string {
+	return "Felt252DictRead"
+}
+
+func (hint Felt252DictRead) Execute(vm *VM.VirtualMachine, ctx *HintRunnerContext) error {
+	dictPtr, err := resolveAsAddress(vm, hint.dictPtr)
+	if err != nil {
+		return fmt.Errorf("resolve dict ptr operand %s: %v", hint.dictPtr, err)
+	}
+	key, err := resolveAsFelt(vm, hint.key)
+	if err != nil {
+		return fmt.Errorf("resolve key operand %s: %v", hint.key, err)
+	}
+
+	dict, err := ctx.DictionaryManager.GetDictionary(dictPtr)
+	if err != nil {
+		return err
+	}
+
+	value := dict.Read(key)
+	dstAddr, err := hint.valueDst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get value destination cell %s: %v", hint.valueDst, err)
+	}
+	if err := vm.Memory.WriteToAddress(&dstAddr, &value); err != nil {
+		return fmt.Errorf("write value to cell %s: %v", dstAddr, err)
+	}
+	return nil
+}
+
+// Sets the value of a key in the dictionary a pointer belongs to, writing the value
+// it had before, or the dictionary default value, to prevValueDst
+type Felt252DictWrite struct {
+	dictPtr      ResOperander
+	key          ResOperander
+	value        ResOperander
+	prevValueDst CellRefer
+}
+
+func (hint Felt252DictWrite) String() string {
+	return "Felt252DictWrite"
+}
+
+func (hint Felt252DictWrite) Execute(vm *VM.VirtualMachine, ctx *HintRunnerContext) error {
+	dictPtr, err := resolveAsAddress(vm, hint.dictPtr)
+	if err != nil {
+		return fmt.Errorf("resolve dict ptr operand %s: %v", hint.dictPtr, err)
+	}
+	key, err := resolveAsFelt(vm, hint.key)
+	if err != nil {
+		return fmt.Errorf("resolve key operand %s: %v", hint.key, err)
+	}
+	value, err := hint.value.Resolve(vm)
+	if err != nil {
+		return fmt.Errorf("resolve value operand %s: %v", hint.value, err)
+	}
+
+	dict, err := ctx.DictionaryManager.GetDictionary(dictPtr)
+	if err != nil {
+		return err
+	}
+
+	prevValue := dict.Write(key, &value)
+	dstAddr, err := hint.prevValueDst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get previous value destination cell %s: %v", hint.prevValueDst, err)
+	}
+	if err := vm.Memory.WriteToAddress(&dstAddr, &prevValue); err != nil {
+		return fmt.Errorf("write previous value to cell %s: %v", dstAddr, err)
+	}
+	return nil
+}
+
+// Writes 1 to dst if a BLS12-381 point, whose coordinates are given as u384 limbs, lies
+// in the prime order subgroup G1, and 0 otherwise. Errors if the point is not on the curve
+type IsInBls12381G1Subgroup struct {
+	x   [4]ResOperander
+	y   [4]ResOperander
+	dst CellRefer
+}
+
+func (hint IsInBls12381G1Subgroup) String() string {
+	return "IsInBls12381G1Subgroup"
+}
+
+func (hint IsInBls12381G1Subgroup) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	x, err := resolveAsUint384(vm, hint.x)
+	if err != nil {
+		return fmt.Errorf("resolve x operand: %v", err)
+	}
+	y, err := resolveAsUint384(vm, hint.y)
+	if err != nil {
+		return fmt.Errorf("resolve y operand: %v", err)
+	}
+
+	for _, coordinate := range []*big.Int{x, y} {
+		if coordinate.Cmp(blsfp.Modulus()) >= 0 {
+			return fmt.Errorf("coordinate %s is not in the BLS12-381 base field", coordinate)
+		}
+	}
+
+	point := bls12381.G1Affine{}
+	point.X.SetBigInt(x)
+	point.Y.SetBigInt(y)
+	if !point.IsOnCurve() {
+		return fmt.Errorf("point (%s, %s) is not on the curve", x, y)
+	}
+
+	inSubgroup := f.Element{}
+	if point.IsInSubGroup() {
+		inSubgroup.SetOne()
+	}
+	return writeFelt(vm, hint.dst, &inSubgroup)
+}
+
+// Initializes the scope of `squash_dict`: groups the indices of the nAccesses dictionary
+// accesses starting at dictAccesses by key, stores the keys in the reverse of the squash
+// order so the first one can be popped, and writes such key to firstKey. Keys are squashed
+// in ascending order unless descending is set. bigKeys is set to 1 if any key doesn't fit
+// in a range check
+type SquashDictInit struct {
+	dictAccesses ResOperander
+	ptrDiff      ResOperander
+	nAccesses    ResOperander
+	bigKeys      CellRefer
+	firstKey     CellRefer
+	descending   bool
+}
+
+func (hint SquashDictInit) String() string {
+	return "SquashDictInit"
+}
+
+func (hint SquashDictInit) Execute(vm *VM.VirtualMachine, ctx *HintRunnerContext) error {
+	dictAccesses, err := resolveAsAddress(vm, hint.dictAccesses)
+	if err != nil {
+		return fmt.Errorf("resolve dict accesses operand %s: %v", hint.dictAccesses, err)
+	}
+	ptrDiff, err := resolveAsUint64(vm, hint.ptrDiff)
+	if err != nil {
+		return fmt.Errorf("resolve ptr diff operand %s: %v", hint.ptrDiff, err)
+	}
+	nAccesses, err := resolveAsUint64(vm, hint.nAccesses)
+	if err != nil {
+		return fmt.Errorf("resolve n accesses operand %s: %v", hint.nAccesses, err)
+	}
+	if ptrDiff%3 != 0 {
+		return fmt.Errorf("accesses array size %d should be divisible by the access size", ptrDiff)
+	}
+
+	accessIndices := make(map[f.Element][]uint64)
+	keys := []f.Element{}
+	for i := uint64(0); i < nAccesses; i++ {
+		mv, err := vm.Memory.Read(dictAccesses.SegmentIndex, dictAccesses.Offset+3*i)
+		if err != nil {
+			return fmt.Errorf("read key of access %d: %v", i, err)
+		}
+		key, err := mv.FieldElement()
+		if err != nil {
+			return fmt.Errorf("read key of access %d: %v", i, err)
+		}
+		if _, ok := accessIndices[*key]; !ok {
+			keys = append(keys, *key)
+		}
+		accessIndices[*key] = append(accessIndices[*key], i)
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("no accesses to squash")
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if hint.descending {
+			return keys[i].Cmp(&keys[j]) < 0
+		}
+		return keys[i].Cmp(&keys[j]) > 0
+	})
+
+	biggestKey := keys[0]
+	if hint.descending {
+		biggestKey = keys[len(keys)-1]
+	}
+	bigKeys := f.Element{}
+	if biggestKey.BigInt(new(big.Int)).BitLen() > 128 {
+		bigKeys.SetOne()
+	}
+	if err := writeFelt(vm, hint.bigKeys, &bigKeys); err != nil {
+		return err
+	}
+
+	key := keys[len(keys)-1]
+	keys = keys[:len(keys)-1]
+	ctx.SetVariable("access_indices", accessIndices)
+	ctx.SetVariable("keys", keys)
+	ctx.SetVariable("key", key)
+	ctx.SetVariable("squash_descending", hint.descending)
+	return writeFelt(vm, hint.firstKey, &key)
+}
+
+// Starts the squashing of the current key: takes its access indices in ascending
+// order and writes the first one to the range check segment
+type SquashDictInnerFirstIteration struct {
+	rangeCheckPtr ResOperander
+}
+
+func (hint SquashDictInnerFirstIteration) String() string {
+	return "SquashDictInnerFirstIteration"
+}
+
+func (hint SquashDictInnerFirstIteration) Execute(vm *VM.VirtualMachine, ctx *HintRunnerContext) error {
+	rangeCheckPtr, err := resolveAsAddress(vm, hint.rangeCheckPtr)
+	if err != nil {
+		return fmt.Errorf("resolve range check pointer %s: %v", hint.rangeCheckPtr, err)
+	}
+
+	accessIndices, err := getVariableAs[map[f.Element][]uint64](ctx, "access_indices")
+	if err != nil {
+		return err
+	}
+	key, err := getVariableAs[f.Element](ctx, "key")
+	if err != nil {
+		return err
+	}
+
+	// sorted in descending order so the smallest index is popped first
+	currentAccessIndices := append([]uint64{}, accessIndices[key]...)
+	if len(currentAccessIndices) == 0 {
+		return fmt.Errorf("no accesses to key %s", &key)
+	}
+	sort.Slice(currentAccessIndices, func(i, j int) bool {
+		return currentAccessIndices[i] > currentAccessIndices[j]
+	})
+
+	last := len(currentAccessIndices) - 1
+	currentAccessIndex := currentAccessIndices[last]
+	ctx.SetVariable("current_access_indices", currentAccessIndices[:last])
+	ctx.SetVariable("current_access_index", currentAccessIndex)
+
+	mv := memory.MemoryValueFromUint(currentAccessIndex)
+	if err := vm.Memory.WriteToAddress(rangeCheckPtr, &mv); err != nil {
+		return fmt.Errorf("write to range check %s: %v", rangeCheckPtr, err)
+	}
+	return nil
+}
+
+// Writes 1 to shouldSkipLoop if the current key has no accesses left, and 0 otherwise
+type SquashDictInnerSkipLoop struct {
+	shouldSkipLoop CellRefer
+}
+
+func (hint SquashDictInnerSkipLoop) String() string {
+	return "SquashDictInnerSkipLoop"
+}
+
+func (hint SquashDictInnerSkipLoop) Execute(vm *VM.VirtualMachine, ctx *HintRunnerContext) error {
+	currentAccessIndices, err := getVariableAs[[]uint64](ctx, "current_access_indices")
+	if err != nil {
+		return err
+	}
+
+	shouldSkipLoop := f.Element{}
+	if len(currentAccessIndices) == 0 {
+		shouldSkipLoop.SetOne()
+	}
+	return writeFelt(vm, hint.shouldSkipLoop, &shouldSkipLoop)
+}
+
+// Moves to the next access of the current key, writing the difference between both
+// access indices minus one to indexDeltaMinus1
+type SquashDictInnerCheckAccessIndex struct {
+	indexDeltaMinus1 CellRefer
+}
+
+func (hint SquashDictInnerCheckAccessIndex) String() string {
+	return "SquashDictInnerCheckAccessIndex"
+}
+
+func (hint SquashDictInnerCheckAccessIndex) Execute(vm *VM.VirtualMachine, ctx *HintRunnerContext) error {
+	currentAccessIndices, err := getVariableAs[[]uint64](ctx, "current_access_indices")
+	if err != nil {
+		return err
+	}
+	currentAccessIndex, err := getVariableAs[uint64](ctx, "current_access_index")
+	if err != nil {
+		return err
+	}
+	if len(currentAccessIndices) == 0 {
+		return fmt.Errorf("no accesses left to check")
+	}
+
+	last := len(currentAccessIndices) - 1
+	newAccessIndex := currentAccessIndices[last]
+	ctx.SetVariable("current_access_indices", currentAccessIndices[:last])
+	ctx.SetVariable("current_access_index", newAccessIndex)
+
+	indexDeltaMinus1 := f.NewElement(newAccessIndex - currentAccessIndex - 1)
+	return writeFelt(vm, hint.indexDeltaMinus1, &indexDeltaMinus1)
+}
+
+// Writes 1 to shouldContinue if the current key has accesses left, and 0 otherwise
+type SquashDictInnerContinueLoop struct {
+	shouldContinue CellRefer
+}
+
+func (hint SquashDictInnerContinueLoop) String() string {
+	return "SquashDictInnerContinueLoop"
+}
+
+func (hint SquashDictInnerContinueLoop) Execute(vm *VM.VirtualMachine, ctx *HintRunnerContext) error {
+	currentAccessIndices, err := getVariableAs[[]uint64](ctx, "current_access_indices")
+	if err != nil {
+		return err
+	}
+
+	shouldContinue := f.Element{}
+	if len(currentAccessIndices) != 0 {
+		shouldContinue.SetOne()
+	}
+	return writeFelt(vm, hint.shouldContinue, &shouldContinue)
+}
+
+// Asserts that every key has been squashed
+type SquashDictInnerAssertLenKeys struct{}
+
+func (hint SquashDictInnerAssertLenKeys) String() string {
+	return "SquashDictInnerAssertLenKeys"
+}
+
+func (hint SquashDictInnerAssertLenKeys) Execute(vm *VM.VirtualMachine, ctx *HintRunnerContext) error {
+	keys, err := getVariableAs[[]f.Element](ctx, "keys")
+	if err != nil {
+		return err
+	}
+	if len(keys) != 0 {
+		return fmt.Errorf("%d keys have not been squashed", len(keys))
+	}
+	return nil
+}
+
+// Moves to the next key to squash, the first one left in the squash order, and writes
+// it to nextKey
+type SquashDictInnerNextKey struct {
+	nextKey CellRefer
+}
+
+func (hint SquashDictInnerNextKey) String() string {
+	return "SquashDictInnerNextKey"
+}
+
+func (hint SquashDictInnerNextKey) Execute(vm *VM.VirtualMachine, ctx *HintRunnerContext) error {
+	keys, err := getVariableAs[[]f.Element](ctx, "keys")
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("no keys left but remaining accesses > 0")
+	}
+
+	key := keys[len(keys)-1]
+	ctx.SetVariable("keys", keys[:len(keys)-1])
+	ctx.SetVariable("key", key)
+	return writeFelt(vm, hint.nextKey, &key)
+}
+
+// Asserts that nextKey strictly follows prevKey in the squash order chosen by
+// SquashDictInit, ascending unless it was configured as descending
+type SquashDictAssertKeyOrder struct {
+	prevKey ResOperander
+	nextKey ResOperander
+}
+
+func (hint SquashDictAssertKeyOrder) String() string {
+	return "SquashDictAssertKeyOrder"
+}
+
+func (hint SquashDictAssertKeyOrder) Execute(vm *VM.VirtualMachine, ctx *HintRunnerContext) error {
+	prevKey, err := resolveAsFelt(vm, hint.prevKey)
+	if err != nil {
+		return fmt.Errorf("resolve prev key operand %s: %v", hint.prevKey, err)
+	}
+	nextKey, err := resolveAsFelt(vm, hint.nextKey)
+	if err != nil {
+		return fmt.Errorf("resolve next key operand %s: %v", hint.nextKey, err)
+	}
+	descending, err := getVariableAs[bool](ctx, "squash_descending")
+	if err != nil {
+		return err
+	}
+
+	if descending {
+		if nextKey.Cmp(prevKey) >= 0 {
+			return fmt.Errorf("keys should be descending: %s is not smaller than %s", nextKey, prevKey)
+		}
+		return nil
+	}
+	if nextKey.Cmp(prevKey) <= 0 {
+		return fmt.Errorf("keys should be ascending: %s is not greater than %s", nextKey, prevKey)
+	}
+	return nil
+}
+
+// Writes the inverses of a source range of field elements into a destination range,
+// using Montgomery's trick so that a single field inversion is computed for the whole batch
+type BatchInverse struct {
+	src    ResOperander
+	dst    ResOperander
+	length ResOperander
+}
+
+func (hint BatchInverse) String() string {
+	return "BatchInverse"
+}
+
+func (hint BatchInverse) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	srcAddr, err := resolveAsAddress(vm, hint.src)
+	if err != nil {
+		return fmt.Errorf("resolve src operand %s: %v", hint.src, err)
+	}
+	dstAddr, err := resolveAsAddress(vm, hint.dst)
+	if err != nil {
+		return fmt.Errorf("resolve dst operand %s: %v", hint.dst, err)
+	}
+	length, err := resolveAsUint64(vm, hint.length)
+	if err != nil {
+		return fmt.Errorf("resolve length operand %s: %v", hint.length, err)
+	}
+
+	values, err := readFelts(vm, srcAddr, length)
+	if err != nil {
+		return err
+	}
+
+	// prefixes[i] holds the product of the values before index i
+	prefixes := make([]f.Element, len(values))
+	product := f.One()
+	for i, value := range values {
+		if value.IsZero() {
+			return fmt.Errorf("cannot invert zero at index %d", i)
+		}
+		prefixes[i] = product
+		product.Mul(&product, value)
+	}
+
+	inverse := new(f.Element).Inverse(&product)
+	for i := len(values) - 1; i >= 0; i-- {
+		// inverse holds the inverse of the product of the values up to index i
+		valueInverse := new(f.Element).Mul(inverse, &prefixes[i])
+		inverse.Mul(inverse, values[i])
+
+		mv := memory.MemoryValueFromFieldElement(valueInverse)
+		if err := vm.Memory.Write(dstAddr.SegmentIndex, dstAddr.Offset+uint64(i), &mv); err != nil {
+			return fmt.Errorf("write inverse %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// Asserts that a field element is not zero, as `assert_not_zero` does
+type AssertNotZero struct {
+	value ResOperander
+}
+
+func (hint AssertNotZero) String() string {
+	return "AssertNotZero"
+}
+
+func (hint AssertNotZero) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	value, err := resolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand %s: %v", hint.value, err)
+	}
+
+	if value.IsZero() {
+		return fmt.Errorf("value = %s should not be zero", value)
+	}
+	return nil
+}
+
+// Asserts that a is strictly smaller than b, both taken as their canonical
+// representatives in [0, p), as `assert_lt_felt` does
+type AssertLtFelt struct {
+	a ResOperander
+	b ResOperander
+}
+
+func (hint AssertLtFelt) String() string {
+	return "AssertLtFelt"
+}
+
+func (hint AssertLtFelt) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	a, err := resolveAsFelt(vm, hint.a)
+	if err != nil {
+		return fmt.Errorf("resolve a operand %s: %v", hint.a, err)
+	}
+	b, err := resolveAsFelt(vm, hint.b)
+	if err != nil {
+		return fmt.Errorf("resolve b operand %s: %v", hint.b, err)
+	}
+
+	if a.Cmp(b) >= 0 {
+		return fmt.Errorf("a = %s is not less than b = %s", a, b)
+	}
+	return nil
+}
+
+// Splits a value as value = scalar * x + y, with x = min(value / scalar, maxX)
+type LinearSplit struct {
+	value  ResOperander
+	scalar ResOperander
+	maxX   ResOperander
+	x      CellRefer
+	y      CellRefer
+}
+
+func (hint LinearSplit) String() string {
+	return "LinearSplit"
+}
+
+func (hint LinearSplit) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	value, err := resolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand %s: %v", hint.value, err)
+	}
+	scalar, err := resolveAsFelt(vm, hint.scalar)
+	if err != nil {
+		return fmt.Errorf("resolve scalar operand %s: %v", hint.scalar, err)
+	}
+	maxX, err := resolveAsFelt(vm, hint.maxX)
+	if err != nil {
+		return fmt.Errorf("resolve max x operand %s: %v", hint.maxX, err)
+	}
+	if scalar.IsZero() {
+		return fmt.Errorf("cannot divide by zero")
+	}
+
+	valueBig := value.BigInt(new(big.Int))
+	scalarBig := scalar.BigInt(new(big.Int))
+	maxXBig := maxX.BigInt(new(big.Int))
+
+	xBig := new(big.Int).Div(valueBig, scalarBig)
+	if xBig.Cmp(maxXBig) > 0 {
+		xBig = maxXBig
+	}
+	yBig := new(big.Int).Mul(xBig, scalarBig)
+	yBig.Sub(valueBig, yBig)
+
+	x := f.Element{}
+	x.SetBigInt(xBig)
+	if err := writeFelt(vm, hint.x, &x); err != nil {
+		return err
+	}
+	y := f.Element{}
+	y.SetBigInt(yBig)
+	return writeFelt(vm, hint.y, &y)
+}
+
+// Commits to a range of values by folding a Pedersen hash chain over them, starting
+// from a blinding factor: c = H(...H(H(blinding, v[0]), v[1])..., v[n - 1])
+type VectorCommit struct {
+	start    ResOperander
+	length   ResOperander
+	blinding ResOperander
+	dst      CellRefer
+}
+
+func (hint VectorCommit) String() string {
+	return "VectorCommit"
+}
+
+func (hint VectorCommit) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	start, err := resolveAsAddress(vm, hint.start)
+	if err != nil {
+		return fmt.Errorf("resolve start operand %s: %v", hint.start, err)
+	}
+	length, err := resolveAsUint64(vm, hint.length)
+	if err != nil {
+		return fmt.Errorf("resolve length operand %s: %v", hint.length, err)
+	}
+	blinding, err := resolveAsFelt(vm, hint.blinding)
+	if err != nil {
+		return fmt.Errorf("resolve blinding operand %s: %v", hint.blinding, err)
+	}
+
+	values, err := readFelts(vm, start, length)
+	if err != nil {
+		return err
+	}
+
+	commitment := *blinding
+	for _, value := range values {
+		commitment = pedersenhash.Pedersen(&commitment, value)
+	}
+	return writeFelt(vm, hint.dst, &commitment)
+}
+
+// Writes the coordinates of a pseudo-random point of the stark curve. Candidate x
+// coordinates are derived by hashing the seed kept in the scope with an increasing
+// counter, until one of them belongs to a point. The seed is bumped afterwards so
+// that successive points differ while the execution stays deterministic
+type RandomEcPoint struct {
+	x CellRefer
+	y CellRefer
+}
+
+func (hint RandomEcPoint) String() string {
+	return "RandomEcPoint"
+}
+
+func (hint RandomEcPoint) Execute(vm *VM.VirtualMachine, ctx *HintRunnerContext) error {
+	seed := uint64(0)
+	if value, err := ctx.GetVariable("random_ec_point_seed"); err == nil {
+		var ok bool
+		if seed, ok = value.(uint64); !ok {
+			return fmt.Errorf("random ec point seed %v should be an uint64", value)
+		}
+	}
+	ctx.SetVariable("random_ec_point_seed", seed+1)
+
+	seedFelt := f.NewElement(seed)
+	for counter := uint64(0); ; counter++ {
+		counterFelt := f.NewElement(counter)
+		x := pedersenhash.Pedersen(&seedFelt, &counterFelt)
+		point, ok := builtins.PointFromX(&x)
+		if !ok {
+			continue
+		}
+
+		if err := writeFelt(vm, hint.x, &point.X); err != nil {
+			return err
+		}
+		return writeFelt(vm, hint.y, &point.Y)
+	}
+}
+
+// Decodes the RLP prefix of the item whose bytes, one per cell, start at data. Writes
+// the length of its payload, the offset of the payload from data, and whether the item
+// is a list. A single byte below 0x80 is its own payload
+type RlpPrefix struct {
+	data   ResOperander
+	length CellRefer
+	offset CellRefer
+	isList CellRefer
+}
+
+func (hint RlpPrefix) String() string {
+	return "RlpPrefix"
+}
+
+func (hint RlpPrefix) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	data, err := resolveAsAddress(vm, hint.data)
+	if err != nil {
+		return fmt.Errorf("resolve data operand %s: %v", hint.data, err)
+	}
+
+	readByte := func(index uint64) (uint64, error) {
+		values, err := readFelts(vm, &memory.MemoryAddress{
+			SegmentIndex: data.SegmentIndex,
+			Offset:       data.Offset + index,
+		}, 1)
+		if err != nil {
+			return 0, fmt.Errorf("read byte %d: %v", index, err)
+		}
+		if !values[0].IsUint64() || values[0].Uint64() > 0xff {
+			return 0, fmt.Errorf("byte %d %s should fit in 8 bits", index, values[0])
+		}
+		return values[0].Uint64(), nil
+	}
+
+	prefix, err := readByte(0)
+	if err != nil {
+		return err
+	}
+
+	var length, offset uint64
+	isList := prefix >= 0xc0
+	// offset of the prefix range of short items, and the bound starting long ones
+	shortBase, longBase := uint64(0x80), uint64(0xb7)
+	if isList {
+		shortBase, longBase = 0xc0, 0xf7
+	}
+
+	switch {
+	case prefix < 0x80:
+		length, offset = 1, 0
+	case prefix <= longBase:
+		length, offset = prefix-shortBase, 1
+	default:
+		lengthOfLength := prefix - longBase
+		for i := uint64(1); i <= lengthOfLength; i++ {
+			b, err := readByte(i)
+			if err != nil {
+				return err
+			}
+			if i == 1 && b == 0 {
+				return fmt.Errorf("long length should not have leading zeros")
+			}
+			length = length<<8 | b
+		}
+		if length <= longBase-shortBase {
+			return fmt.Errorf("long length %d should be encoded as a short one", length)
+		}
+		offset = 1 + lengthOfLength
+	}
+
+	lengthFelt := f.NewElement(length)
+	if err := writeFelt(vm, hint.length, &lengthFelt); err != nil {
+		return err
+	}
+	offsetFelt := f.NewElement(offset)
+	if err := writeFelt(vm, hint.offset, &offsetFelt); err != nil {
+		return err
+	}
+	isListFelt := f.Element{}
+	if isList {
+		isListFelt.SetOne()
+	}
+	return writeFelt(vm, hint.isList, &isListFelt)
+}
+
+// Asserts that a value is a valid StarkNet class hash, i.e. that it is smaller than 2**251
+type AssertClassHash struct {
+	value ResOperander
+}
+
+func (hint AssertClassHash) String() string {
+	return "AssertClassHash"
+}
+
+func (hint AssertClassHash) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	value, err := resolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand %s: %v", hint.value, err)
+	}
+
+	if value.BigInt(new(big.Int)).Cmp(classHashBound) >= 0 {
+		return fmt.Errorf("value %s is not a valid class hash", value)
+	}
+	return nil
+}
+
+// Bytes packed in every full word of a ByteArray
+const byteArrayWordBytes = 31
+
+// Computes the keccak256 hash of the bytes of a ByteArray, given as its full 31 bytes
+// words, followed by the pending word holding its last pendingWordLen bytes. Every word
+// is big-endian. The hash is written as its low and high 128 bits limbs
+type KeccakByteArray struct {
+	data           ResOperander
+	dataLen        ResOperander
+	pendingWord    ResOperander
+	pendingWordLen ResOperander
+	low            CellRefer
+	high           CellRefer
+}
+
+func (hint KeccakByteArray) String() string {
+	return "KeccakByteArray"
+}
+
+func (hint KeccakByteArray) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	dataAddr, err := resolveAsAddress(vm, hint.data)
+	if err != nil {
+		return fmt.Errorf("resolve data operand %s: %v", hint.data, err)
+	}
+	dataLen, err := resolveAsUint64(vm, hint.dataLen)
+	if err != nil {
+		return fmt.Errorf("resolve data len operand %s: %v", hint.dataLen, err)
+	}
+	pendingWord, err := resolveAsFelt(vm, hint.pendingWord)
+	if err != nil {
+		return fmt.Errorf("resolve pending word operand %s: %v", hint.pendingWord, err)
+	}
+	pendingWordLen, err := resolveAsUint64(vm, hint.pendingWordLen)
+	if err != nil {
+		return fmt.Errorf("resolve pending word len operand %s: %v", hint.pendingWordLen, err)
+	}
+	if pendingWordLen >= byteArrayWordBytes {
+		return fmt.Errorf("pending word len %d should be smaller than %d", pendingWordLen, byteArrayWordBytes)
+	}
+
+	words, err := readFelts(vm, dataAddr, dataLen)
+	if err != nil {
+		return err
+	}
+
+	hasher := sha3.NewLegacyKeccak256()
+	for i, word := range words {
+		wordBig := word.BigInt(new(big.Int))
+		if wordBig.BitLen() > 8*byteArrayWordBytes {
+			return fmt.Errorf("word %d %s should fit in %d bytes", i, word, byteArrayWordBytes)
+		}
+
+		var bytes [byteArrayWordBytes]byte
+		wordBig.FillBytes(bytes[:])
+		hasher.Write(bytes[:])
+	}
+
+	pendingWordBig := pendingWord.BigInt(new(big.Int))
+	if pendingWordBig.BitLen() > int(8*pendingWordLen) {
+		return fmt.Errorf("pending word %s should fit in %d bytes", pendingWord, pendingWordLen)
+	}
+	pendingBytes := make([]byte, pendingWordLen)
+	pendingWordBig.FillBytes(pendingBytes)
+	hasher.Write(pendingBytes)
+
+	hash := new(big.Int).SetBytes(hasher.Sum(nil))
+	return writeUint256(vm, hint.low, hint.high, hash)
+}
+
+// Applies the sha256 compression function to a state of 8 words with a block of 16
+// words, every word being a 32 bits big-endian value stored in its own cell, and
+// writes the 8 words of the new state to dst
+type Sha256Compress struct {
+	state ResOperander
+	block ResOperander
+	dst   ResOperander
+}
+
+func (hint Sha256Compress) String() string {
+	return "Sha256Compress"
+}
+
+func (hint Sha256Compress) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	stateAddr, err := resolveAsAddress(vm, hint.state)
+	if err != nil {
+		return fmt.Errorf("resolve state operand %s: %v", hint.state, err)
+	}
+	blockAddr, err := resolveAsAddress(vm, hint.block)
+	if err != nil {
+		return fmt.Errorf("resolve block operand %s: %v", hint.block, err)
+	}
+	dstAddr, err := resolveAsAddress(vm, hint.dst)
+	if err != nil {
+		return fmt.Errorf("resolve dst operand %s: %v", hint.dst, err)
+	}
+
+	readWords := func(address *memory.MemoryAddress, words []uint32) error {
+		felts, err := readFelts(vm, address, uint64(len(words)))
+		if err != nil {
+			return err
+		}
+		for i, felt := range felts {
+			if !felt.IsUint64() || felt.Uint64() > 0xffffffff {
+				return fmt.Errorf("word %d %s should be u32", i, felt)
+			}
+			words[i] = uint32(felt.Uint64())
+		}
+		return nil
+	}
+
+	state := [8]uint32{}
+	if err := readWords(stateAddr, state[:]); err != nil {
+		return fmt.Errorf("read state: %v", err)
+	}
+	block := [16]uint32{}
+	if err := readWords(blockAddr, block[:]); err != nil {
+		return fmt.Errorf("read block: %v", err)
+	}
+
+	sha256Compress(&state, &block)
+	for i, word := range state {
+		mv := memory.MemoryValueFromUint(uint64(word))
+		if err := vm.Memory.Write(dstAddr.SegmentIndex, dstAddr.Offset+uint64(i), &mv); err != nil {
+			return fmt.Errorf("write state word %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// Writes floor(log_base(value)) to dst, computed by dividing value by base until it
+// becomes smaller than it. Errors if value is 0 or base is smaller than 2
+type LogBase struct {
+	value ResOperander
+	base  ResOperander
+	dst   CellRefer
+}
+
+func (hint LogBase) String() string {
+	return "LogBase"
+}
+
+func (hint LogBase) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	value, err := resolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand %s: %v", hint.value, err)
+	}
+	base, err := resolveAsFelt(vm, hint.base)
+	if err != nil {
+		return fmt.Errorf("resolve base operand %s: %v", hint.base, err)
+	}
+
+	valueBig := value.BigInt(new(big.Int))
+	baseBig := base.BigInt(new(big.Int))
+	if valueBig.Sign() == 0 {
+		return fmt.Errorf("log of 0 is undefined")
+	}
+	if baseBig.Cmp(big.NewInt(2)) < 0 {
+		return fmt.Errorf("base %s should be at least 2", base)
+	}
+
+	log := uint64(0)
+	for valueBig.Cmp(baseBig) >= 0 {
+		valueBig.Quo(valueBig, baseBig)
+		log++
+	}
+
+	result := f.NewElement(log)
+	return writeFelt(vm, hint.dst, &result)
+}
+
+// Asserts that a value is a valid short string: once decoded as big-endian bytes it
+// must be at most 31 printable ASCII characters, without null bytes in the middle
+type AssertShortString struct {
+	value ResOperander
+}
+
+func (hint AssertShortString) String() string {
+	return "AssertShortString"
+}
+
+func (hint AssertShortString) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	value, err := resolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand %s: %v", hint.value, err)
+	}
+
+	// leading zeros are not part of the string, so any null byte left is embedded
+	bytes := value.BigInt(new(big.Int)).Bytes()
+	if len(bytes) > byteArrayWordBytes {
+		return fmt.Errorf("short string %s is %d bytes long, more than %d", value, len(bytes), byteArrayWordBytes)
+	}
+	for i, b := range bytes {
+		if b == 0 {
+			return fmt.Errorf("short string %s has a null byte at position %d", value, i)
+		}
+		if b < 0x20 || b > 0x7e {
+			return fmt.Errorf("short string %s has a non printable byte 0x%02x at position %d", value, b, i)
+		}
+	}
+	return nil
+}
+
+// Decomposes a value into nParts parts of bitWidth bits, from the least significant one,
+// and writes them to the range check segment starting at rangeCheckPtr. Errors if the
+// parts don't recompose the original value, that is if it doesn't fit in them
+type RangeCheckDecompose struct {
+	rangeCheckPtr ResOperander
+	value         ResOperander
+	nParts        ResOperander
+	bitWidth      ResOperander
+}
+
+func (hint RangeCheckDecompose) String() string {
+	return "RangeCheckDecompose"
+}
+
+func (hint RangeCheckDecompose) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	rangeCheckPtr, err := resolveAsAddress(vm, hint.rangeCheckPtr)
+	if err != nil {
+		return fmt.Errorf("resolve range check pointer %s: %v", hint.rangeCheckPtr, err)
+	}
+	value, err := resolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand %s: %v", hint.value, err)
+	}
+	nParts, err := resolveAsUint64(vm, hint.nParts)
+	if err != nil {
+		return fmt.Errorf("resolve n parts operand %s: %v", hint.nParts, err)
+	}
+	bitWidth, err := resolveAsUint64(vm, hint.bitWidth)
+	if err != nil {
+		return fmt.Errorf("resolve bit width operand %s: %v", hint.bitWidth, err)
+	}
+	if bitWidth == 0 || bitWidth > 128 {
+		return fmt.Errorf("bit width %d should be between 1 and 128", bitWidth)
+	}
+
+	valueBig := value.BigInt(new(big.Int))
+	mask := new(big.Int).Lsh(big.NewInt(1), uint(bitWidth))
+	mask.Sub(mask, big.NewInt(1))
+
+	rest := new(big.Int).Set(valueBig)
+	parts := make([]*big.Int, nParts)
+	recomposed := new(big.Int)
+	for i := range parts {
+		parts[i] = new(big.Int).And(rest, mask)
+		rest.Rsh(rest, uint(bitWidth))
+		recomposed.Or(recomposed, new(big.Int).Lsh(parts[i], uint(i)*uint(bitWidth)))
+	}
+	if recomposed.Cmp(valueBig) != 0 {
+		return fmt.Errorf("value %s doesn't fit in %d parts of %d bits", value, nParts, bitWidth)
+	}
+
+	for i, part := range parts {
+		felt := f.Element{}
+		felt.SetBigInt(part)
+		mv := memory.MemoryValueFromFieldElement(&felt)
+		offset := rangeCheckPtr.Offset + uint64(i)
+		if err := vm.Memory.Write(rangeCheckPtr.SegmentIndex, offset, &mv); err != nil {
+			return fmt.Errorf("write part %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// Writes to dst the length of the overlap of the [a1, a2] and [b1, b2] intervals,
+// which is 0 if they are disjoint or only touch at an end. Every bound is interpreted
+// as a field integer
+type IntervalOverlap struct {
+	a1  ResOperander
+	a2  ResOperander
+	b1  ResOperander
+	b2  ResOperander
+	dst CellRefer
+}
+
+func (hint IntervalOverlap) String() string {
+	return "IntervalOverlap"
+}
+
+func (hint IntervalOverlap) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	bounds := [4]*f.Element{}
+	for i, operand := range []ResOperander{hint.a1, hint.a2, hint.b1, hint.b2} {
+		bound, err := resolveAsFelt(vm, operand)
+		if err != nil {
+			return fmt.Errorf("resolve bound operand %s: %v", operand, err)
+		}
+		bounds[i] = bound
+	}
+	a1, a2, b1, b2 := bounds[0], bounds[1], bounds[2], bounds[3]
+	if a1.Cmp(a2) > 0 {
+		return fmt.Errorf("interval [%s, %s] has its start after its end", a1, a2)
+	}
+	if b1.Cmp(b2) > 0 {
+		return fmt.Errorf("interval [%s, %s] has its start after its end", b1, b2)
+	}
+
+	start := a1
+	if b1.Cmp(a1) > 0 {
+		start = b1
+	}
+	end := a2
+	if b2.Cmp(a2) < 0 {
+		end = b2
+	}
+
+	overlap := f.Element{}
+	if end.Cmp(start) > 0 {
+		overlap.Sub(end, start)
+	}
+	return writeFelt(vm, hint.dst, &overlap)
+}
+
+// Asserts that a squashed dictionary is last-write-wins: for every DictAccess triple
+// (key, prev_value, new_value) between squashedStart and squashedEnd, new_value must
+// equal the last value written to the key in the dictionary dictPtr points into
+type AssertSquashedDictLastWrite struct {
+	dictPtr       ResOperander
+	squashedStart ResOperander
+	squashedEnd   ResOperander
+}
+
+func (hint AssertSquashedDictLastWrite) String() string {
+	return "AssertSquashedDictLastWrite"
+}
+
+func (hint AssertSquashedDictLastWrite) Execute(vm *VM.VirtualMachine, ctx *HintRunnerContext) error {
+	dictPtr, err := resolveAsAddress(vm, hint.dictPtr)
+	if err != nil {
+		return fmt.Errorf("resolve dict pointer operand %s: %v", hint.dictPtr, err)
+	}
+	squashedStart, err := resolveAsAddress(vm, hint.squashedStart)
+	if err != nil {
+		return fmt.Errorf("resolve squashed start operand %s: %v", hint.squashedStart, err)
+	}
+	squashedEnd, err := resolveAsAddress(vm, hint.squashedEnd)
+	if err != nil {
+		return fmt.Errorf("resolve squashed end operand %s: %v", hint.squashedEnd, err)
+	}
+
+	dict, err := ctx.DictionaryManager.GetDictionary(dictPtr)
+	if err != nil {
+		return err
+	}
+	if squashedStart.SegmentIndex != squashedEnd.SegmentIndex || squashedStart.Offset > squashedEnd.Offset {
+		return fmt.Errorf("squashed dict end %s is not after its start %s", squashedEnd, squashedStart)
+	}
+	size := squashedEnd.Offset - squashedStart.Offset
+	if size%3 != 0 {
+		return fmt.Errorf("squashed dict size %d is not a multiple of the access size", size)
+	}
+
+	for i := uint64(0); i < size/3; i++ {
+		offset := squashedStart.Offset + 3*i
+		keyValue, err := vm.Memory.Read(squashedStart.SegmentIndex, offset)
+		if err != nil {
+			return fmt.Errorf("read key of access %d: %v", i, err)
+		}
+		key, err := keyValue.FieldElement()
+		if err != nil {
+			return fmt.Errorf("read key of access %d: %v", i, err)
+		}
+		newValue, err := vm.Memory.Read(squashedStart.SegmentIndex, offset+2)
+		if err != nil {
+			return fmt.Errorf("read new value of access %d: %v", i, err)
+		}
+
+		lastWrite := dict.Peek(key)
+		if !newValue.Equal(&lastWrite) {
+			return fmt.Errorf(
+				"key %s: squashed new value %s differs from the last write %s",
+				key, &newValue, &lastWrite,
+			)
+		}
+	}
+	return nil
+}
+
+// Writes to dst the root of the Merkle tree whose leaves are the length field
+// elements starting at start, every node being the Poseidon hash of its two children.
+// Errors if length is not a power of two
+type PoseidonMerkleRoot struct {
+	start  ResOperander
+	length ResOperander
+	dst    CellRefer
+}
+
+func (hint PoseidonMerkleRoot) String() string {
+	return "PoseidonMerkleRoot"
+}
+
+func (hint PoseidonMerkleRoot) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	start, err := resolveAsAddress(vm, hint.start)
+	if err != nil {
+		return fmt.Errorf("resolve start operand %s: %v", hint.start, err)
+	}
+	length, err := resolveAsUint64(vm, hint.length)
+	if err != nil {
+		return fmt.Errorf("resolve length operand %s: %v", hint.length, err)
+	}
+	if length == 0 || length&(length-1) != 0 {
+		return fmt.Errorf("length %d is not a power of two", length)
+	}
+
+	nodes, err := readFelts(vm, start, length)
+	if err != nil {
+		return fmt.Errorf("read leaves: %v", err)
+	}
+	for len(nodes) > 1 {
+		for i := 0; i < len(nodes)/2; i++ {
+			parent := builtins.PoseidonHash(nodes[2*i], nodes[2*i+1])
+			nodes[i] = &parent
+		}
+		nodes = nodes[:len(nodes)/2]
+	}
+	return writeFelt(vm, hint.dst, nodes[0])
+}
+
+// Delta encodes a source range of field elements into a destination range, writing
+// out[0] = in[0] and out[i] = in[i] - in[i-1] for the rest, in the field
+type Delta struct {
+	src    ResOperander
+	dst    ResOperander
+	length ResOperander
+}
+
+func (hint Delta) String() string {
+	return "Delta"
+}
+
+func (hint Delta) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	srcAddr, err := resolveAsAddress(vm, hint.src)
+	if err != nil {
+		return fmt.Errorf("resolve src operand %s: %v", hint.src, err)
+	}
+	dstAddr, err := resolveAsAddress(vm, hint.dst)
+	if err != nil {
+		return fmt.Errorf("resolve dst operand %s: %v", hint.dst, err)
+	}
+	length, err := resolveAsUint64(vm, hint.length)
+	if err != nil {
+		return fmt.Errorf("resolve length operand %s: %v", hint.length, err)
+	}
+
+	values, err := readFelts(vm, srcAddr, length)
+	if err != nil {
+		return err
+	}
+
+	previous := f.Element{}
+	delta := f.Element{}
+	for i, value := range values {
+		delta.Sub(value, &previous)
+		previous = *value
+
+		mv := memory.MemoryValueFromFieldElement(&delta)
+		if err := vm.Memory.Write(dstAddr.SegmentIndex, dstAddr.Offset+uint64(i), &mv); err != nil {
+			return fmt.Errorf("write delta %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// Reverts the Delta encoding of a source range of field elements, writing to the
+// destination range its prefix sums out[i] = in[0] + ... + in[i], in the field
+type UnDelta struct {
+	src    ResOperander
+	dst    ResOperander
+	length ResOperander
+}
+
+func (hint UnDelta) String() string {
+	return "UnDelta"
+}
+
+// Undoing the delta encoding amounts to taking the prefix sums of the deltas
+func (hint UnDelta) Execute(vm *VM.VirtualMachine, ctx *HintRunnerContext) error {
+	return PrefixSum{src: hint.src, dst: hint.dst, length: hint.length}.Execute(vm, ctx)
+}
+
+// Asserts that the length pointers starting at start all point to the same segment
+// with nondecreasing offsets, reporting the first pair out of order
+type AssertNondecreasingPointers struct {
+	start  ResOperander
+	length ResOperander
+}
+
+func (hint AssertNondecreasingPointers) String() string {
+	return "AssertNondecreasingPointers"
+}
+
+func (hint AssertNondecreasingPointers) Execute(vm *VM.VirtualMachine, _ *HintRunnerContext) error {
+	start, err := resolveAsAddress(vm, hint.start)
+	if err != nil {
+		return fmt.Errorf("resolve start operand %s: %v", hint.start, err)
+	}
+	length, err := resolveAsUint64(vm, hint.length)
+	if err != nil {
+		return fmt.Errorf("resolve length operand %s: %v", hint.length, err)
+	}
+
+	var previous *memory.MemoryAddress
+	for i := uint64(0); i < length; i++ {
+		mv, err := vm.Memory.Read(start.SegmentIndex, start.Offset+i)
+		if err != nil {
+			return fmt.Errorf("read pointer %d: %v", i, err)
+		}
+		pointer, err := mv.MemoryAddress()
+		if err != nil {
+			return fmt.Errorf("read pointer %d: %v", i, err)
+		}
+
+		if previous != nil {
+			if pointer.SegmentIndex != previous.SegmentIndex {
+				return fmt.Errorf(
+					"pointer %d %s is not in the same segment as pointer %d %s",
+					i, pointer, i-1, previous,
+				)
+			}
+			if pointer.Offset < previous.Offset {
+				return fmt.Errorf(
+					"pointer %d %s is smaller than pointer %d %s",
+					i, pointer, i-1, previous,
+				)
+			}
+		}
+		previous = pointer
+	}
+	return nil
+}
+
+// Consumes the gas cost of the next instructions from the gas available to the
+// execution, erroring if there isn't enough left
+type ConsumeGas struct {
+	amount ResOperander
+}
+
+func (hint ConsumeGas) String() string {
+	return "ConsumeGas"
+}
+
+func (hint ConsumeGas) Execute(vm *VM.VirtualMachine, ctx *HintRunnerContext) error {
+	amount, err := resolveAsUint64(vm, hint.amount)
+	if err != nil {
+		return fmt.Errorf("resolve amount operand %s: %v", hint.amount, err)
+	}
+	return ctx.ConsumeGas(amount)
+}
+
+// Writes the gas still available to the execution to dst
+type GetAvailableGas struct {
+	dst CellRefer
+}
+
+func (hint GetAvailableGas) String() string {
+	return "GetAvailableGas"
+}
+
+func (hint GetAvailableGas) Execute(vm *VM.VirtualMachine, ctx *HintRunnerContext) error {
+	gas := f.NewElement(ctx.AvailableGas())
+	return writeFelt(vm, hint.dst, &gas)
+}
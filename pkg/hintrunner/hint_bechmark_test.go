@@ -17,7 +17,7 @@ func BenchmarkAllocSegment(b *testing.B) {
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		alloc := AllocSegment{ap}
-		err := alloc.Execute(vm)
+		err := alloc.Execute(vm, nil)
 		if err != nil {
 			b.Error(err)
 			break
@@ -48,7 +48,7 @@ func BenchmarkLessThan(b *testing.B) {
 			rhs: rhs,
 		}
 
-		err := hint.Execute(vm)
+		err := hint.Execute(vm, nil)
 		if err != nil {
 			b.Error(err)
 			break
@@ -75,7 +75,7 @@ func BenchmarkSquareRoot(b *testing.B) {
 			dst:   dst,
 		}
 
-		err := hint.Execute(vm)
+		err := hint.Execute(vm, nil)
 		if err != nil {
 			b.Error(err)
 			break
@@ -106,7 +106,7 @@ func BenchmarkWideMul128(b *testing.B) {
 			rhs:  rhs,
 		}
 
-		err := hint.Execute(vm)
+		err := hint.Execute(vm, nil)
 		if err != nil {
 			b.Error(err)
 			break
@@ -1,13 +1,16 @@
 package hintrunner
 
 import (
+	"fmt"
 	"io"
 	"math/big"
 	"os"
 	"testing"
 
 	VM "github.com/NethermindEth/cairo-vm-go/pkg/vm"
+	"github.com/NethermindEth/cairo-vm-go/pkg/vm/builtins"
 	"github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
+	starkcurve "github.com/consensys/gnark-crypto/ecc/stark-curve"
 	f "github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
 	"github.com/stretchr/testify/require"
 )
@@ -23,7 +26,7 @@ func TestAllocSegment(t *testing.T) {
 	alloc1 := AllocSegment{ap}
 	alloc2 := AllocSegment{fp}
 
-	err := alloc1.Execute(vm)
+	err := alloc1.Execute(vm, nil)
 	require.Nil(t, err)
 	require.Equal(t, 3, len(vm.Memory.Segments))
 	require.Equal(
@@ -32,7 +35,7 @@ func TestAllocSegment(t *testing.T) {
 		readFrom(vm, VM.ExecutionSegment, vm.Context.Ap+5),
 	)
 
-	err = alloc2.Execute(vm)
+	err = alloc2.Execute(vm, nil)
 	require.Nil(t, err)
 	require.Equal(t, 4, len(vm.Memory.Segments))
 	require.Equal(
@@ -61,7 +64,7 @@ func TestTestLessThanTrue(t *testing.T) {
 		rhs: rhs,
 	}
 
-	err := hint.Execute(vm)
+	err := hint.Execute(vm, nil)
 	require.NoError(t, err)
 	require.Equal(
 		t,
@@ -97,7 +100,7 @@ func TestTestLessThanFalse(t *testing.T) {
 				rhs: rhs,
 			}
 
-			err := hint.Execute(vm)
+			err := hint.Execute(vm, nil)
 			require.NoError(t, err)
 			require.Equal(
 				t,
@@ -136,7 +139,7 @@ func TestTestLessThanOrEqTrue(t *testing.T) {
 				rhs: rhs,
 			}
 
-			err := hint.Execute(vm)
+			err := hint.Execute(vm, nil)
 			require.NoError(t, err)
 			require.Equal(
 				t,
@@ -166,7 +169,7 @@ func TestTestLessThanOrEqFalse(t *testing.T) {
 		rhs: rhs,
 	}
 
-	err := hint.Execute(vm)
+	err := hint.Execute(vm, nil)
 	require.NoError(t, err)
 	require.Equal(
 		t,
@@ -194,7 +197,7 @@ func TestWideMul128(t *testing.T) {
 		rhs:  rhs,
 	}
 
-	err := hint.Execute(vm)
+	err := hint.Execute(vm, nil)
 	require.Nil(t, err)
 
 	low := &f.Element{}
@@ -230,7 +233,7 @@ func TestWideMul128IncorrectRange(t *testing.T) {
 		rhs:  rhs,
 	}
 
-	err := hint.Execute(vm)
+	err := hint.Execute(vm, nil)
 	require.ErrorContains(t, err, "should be u128")
 }
 
@@ -259,7 +262,7 @@ func TestDebugPrint(t *testing.T) {
 		end:   end,
 	}
 	expected := []byte("[DEBUG] a\n[DEBUG] 14\n[DEBUG] 1e\n")
-	err := hint.Execute(vm)
+	err := hint.Execute(vm, nil)
 
 	w.Close()
 	out, _ := io.ReadAll(r)
@@ -282,7 +285,7 @@ func TestSquareRoot(t *testing.T) {
 		dst:   dst,
 	}
 
-	err := hint.Execute(vm)
+	err := hint.Execute(vm, nil)
 
 	require.NoError(t, err)
 	require.Equal(
@@ -291,3 +294,3733 @@ func TestSquareRoot(t *testing.T) {
 		readFrom(vm, VM.ExecutionSegment, 1),
 	)
 }
+
+func TestBytesToFelt252(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	felt, err := new(f.Element).SetString("0x03d937c035c878245caf64531a5756109c53068da139362728feb561405371cb")
+	require.NoError(t, err)
+
+	bytesSegment := vm.Memory.AllocateEmptySegment()
+	for i, b := range felt.Bytes() {
+		writeTo(vm, uint64(bytesSegment), uint64(i), memory.MemoryValueFromUint(b))
+	}
+	writeTo(vm, VM.ExecutionSegment, 0, memory.MemoryValueFromSegmentAndOffset(bytesSegment, 0))
+
+	var bytesRef ApCellRef = 0
+	var dst ApCellRef = 1
+	hint := BytesToFelt252{
+		bytes: Deref{bytesRef},
+		dst:   dst,
+	}
+
+	err = hint.Execute(vm, nil)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		memory.MemoryValueFromFieldElement(felt),
+		readFrom(vm, VM.ExecutionSegment, 1),
+	)
+}
+
+func TestFelt252ToBytesRoundTrip(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	felt, err := new(f.Element).SetString("0x0208a0a10250e382e1e4bbe2880906c2791bf6275695e02fbbc6aeff9cd8b31a")
+	require.NoError(t, err)
+
+	bytesSegment := vm.Memory.AllocateEmptySegment()
+	writeTo(vm, VM.ExecutionSegment, 0, memory.MemoryValueFromFieldElement(felt))
+	writeTo(vm, VM.ExecutionSegment, 1, memory.MemoryValueFromSegmentAndOffset(bytesSegment, 0))
+
+	var valueRef ApCellRef = 0
+	var bytesRef ApCellRef = 1
+	var dst ApCellRef = 2
+	split := Felt252ToBytes{
+		value: Deref{valueRef},
+		bytes: Deref{bytesRef},
+	}
+	err = split.Execute(vm, nil)
+	require.NoError(t, err)
+
+	// the most significant byte comes first
+	require.Equal(t, memory.MemoryValueFromInt(0x02), readFrom(vm, uint64(bytesSegment), 0))
+	require.Equal(t, memory.MemoryValueFromInt(0x1a), readFrom(vm, uint64(bytesSegment), 31))
+
+	recombine := BytesToFelt252{
+		bytes: Deref{bytesRef},
+		dst:   dst,
+	}
+	err = recombine.Execute(vm, nil)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		memory.MemoryValueFromFieldElement(felt),
+		readFrom(vm, VM.ExecutionSegment, 2),
+	)
+}
+
+func TestBytesToFelt252Overflow(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	bytesSegment := vm.Memory.AllocateEmptySegment()
+	for i := 0; i < 32; i++ {
+		writeTo(vm, uint64(bytesSegment), uint64(i), memory.MemoryValueFromInt(255))
+	}
+	writeTo(vm, VM.ExecutionSegment, 0, memory.MemoryValueFromSegmentAndOffset(bytesSegment, 0))
+
+	var bytesRef ApCellRef = 0
+	var dst ApCellRef = 1
+	hint := BytesToFelt252{
+		bytes: Deref{bytesRef},
+		dst:   dst,
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "exceeds the field modulus")
+}
+
+func TestUint256AddMod(t *testing.T) {
+	testCases := []struct {
+		name         string
+		aLow, aHigh  int64
+		bLow, bHigh  int64
+		nLow, nHigh  int64
+		expectedLow  uint64
+		expectedHigh uint64
+	}{
+		{"sum below the modulus", 5, 1, 7, 0, 100, 1, 12, 1},
+		{"sum wraps the modulus", 5, 1, 7, 0, 10, 1, 2, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			var resultLow ApCellRef = 1
+			var resultHigh ApCellRef = 2
+			hint := Uint256AddMod{
+				aLow:       Immediate(*big.NewInt(tc.aLow)),
+				aHigh:      Immediate(*big.NewInt(tc.aHigh)),
+				bLow:       Immediate(*big.NewInt(tc.bLow)),
+				bHigh:      Immediate(*big.NewInt(tc.bHigh)),
+				nLow:       Immediate(*big.NewInt(tc.nLow)),
+				nHigh:      Immediate(*big.NewInt(tc.nHigh)),
+				resultLow:  resultLow,
+				resultHigh: resultHigh,
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(t, memory.MemoryValueFromUint(tc.expectedLow), readFrom(vm, VM.ExecutionSegment, 1))
+			require.Equal(t, memory.MemoryValueFromUint(tc.expectedHigh), readFrom(vm, VM.ExecutionSegment, 2))
+		})
+	}
+}
+
+func TestUint256SubMod(t *testing.T) {
+	testCases := []struct {
+		name         string
+		aLow, aHigh  int64
+		bLow, bHigh  int64
+		nLow, nHigh  int64
+		expectedLow  uint64
+		expectedHigh uint64
+	}{
+		// 2**128 + 12 - 5
+		{"a greater than b", 12, 1, 5, 0, 100, 1, 7, 1},
+		{"a equal to b", 12, 1, 12, 1, 100, 1, 0, 0},
+		// 5 - 7 + 2**128 + 10
+		{"a smaller than b", 5, 0, 7, 0, 10, 1, 8, 1},
+		// (2**128 + 7 - 3) mod 10
+		{"difference above the modulus", 7, 1, 3, 0, 10, 0, 0, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			var resultLow ApCellRef = 1
+			var resultHigh ApCellRef = 2
+			hint := Uint256SubMod{
+				aLow:       Immediate(*big.NewInt(tc.aLow)),
+				aHigh:      Immediate(*big.NewInt(tc.aHigh)),
+				bLow:       Immediate(*big.NewInt(tc.bLow)),
+				bHigh:      Immediate(*big.NewInt(tc.bHigh)),
+				nLow:       Immediate(*big.NewInt(tc.nLow)),
+				nHigh:      Immediate(*big.NewInt(tc.nHigh)),
+				resultLow:  resultLow,
+				resultHigh: resultHigh,
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(t, memory.MemoryValueFromUint(tc.expectedLow), readFrom(vm, VM.ExecutionSegment, 1))
+			require.Equal(t, memory.MemoryValueFromUint(tc.expectedHigh), readFrom(vm, VM.ExecutionSegment, 2))
+		})
+	}
+}
+
+func TestEcMul(t *testing.T) {
+	_, generator := starkcurve.Generators()
+
+	for _, k := range []int64{0, 1, 2, 3, 7, 100} {
+		t.Run(fmt.Sprintf("k=%d", k), func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			var dstX ApCellRef = 1
+			var dstY ApCellRef = 2
+			hint := EcMul{
+				x:      Immediate(*generator.X.BigInt(new(big.Int))),
+				y:      Immediate(*generator.Y.BigInt(new(big.Int))),
+				scalar: Immediate(*big.NewInt(k)),
+				dstX:   dstX,
+				dstY:   dstY,
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+
+			expected := starkcurve.G1Affine{}
+			expected.ScalarMultiplication(&generator, big.NewInt(k))
+			require.Equal(t, memory.MemoryValueFromFieldElement(&expected.X), readFrom(vm, VM.ExecutionSegment, 1))
+			require.Equal(t, memory.MemoryValueFromFieldElement(&expected.Y), readFrom(vm, VM.ExecutionSegment, 2))
+		})
+	}
+}
+
+func TestEcMulOffCurve(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	var dstX ApCellRef = 1
+	var dstY ApCellRef = 2
+	hint := EcMul{
+		x:      Immediate(*big.NewInt(1)),
+		y:      Immediate(*big.NewInt(2)),
+		scalar: Immediate(*big.NewInt(2)),
+		dstX:   dstX,
+		dstY:   dstY,
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "is not on the curve")
+}
+
+func TestEcdsaVerify(t *testing.T) {
+	hexToBig := func(hex string) *big.Int {
+		value, _ := new(big.Int).SetString(hex, 16)
+		return value
+	}
+
+	// StarkEx crypto test vector, signed with the private key
+	// 0x3c1e9550e66958296d11b60f8e8e7a7ad990d07fa65d5f7652c4a6c87d4e3cc
+	publicKey := hexToBig("77a3b314db07c45076d11f62b6f9e748a39790441823307743cf00d6597ea43")
+	message := hexToBig("397e76d1667c4454bfb83514e120583af836f8e32a516765497823eabe16a3f")
+	r := hexToBig("173fd03d8b008ee7432977ac27d1e9d1a1f6c98b1a2f05fa84a21c84c44e882")
+	s := hexToBig("4b6d75385aed025aa222f28a0adc6d58db78ff17e51c3f59e259b131cd5a1cc")
+
+	testCases := []struct {
+		name        string
+		s           *big.Int
+		expectedErr string
+	}{
+		{"valid signature", s, ""},
+		{"invalid signature", new(big.Int).Add(s, big.NewInt(1)), "invalid signature"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+			hint := EcdsaVerify{
+				publicKey: Immediate(*publicKey),
+				message:   Immediate(*message),
+				r:         Immediate(*r),
+				s:         Immediate(*tc.s),
+			}
+
+			err := hint.Execute(vm, nil)
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestClamp(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    int64
+		expected uint64
+	}{
+		{"below range", 3, 10},
+		{"in range", 15, 15},
+		{"above range", 25, 20},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			var dst ApCellRef = 1
+			hint := Clamp{
+				value: Immediate(*big.NewInt(tc.value)),
+				min:   Immediate(*big.NewInt(10)),
+				max:   Immediate(*big.NewInt(20)),
+				dst:   dst,
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(t, memory.MemoryValueFromUint(tc.expected), readFrom(vm, VM.ExecutionSegment, 1))
+		})
+	}
+}
+
+func TestClampInvalidBounds(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	var dst ApCellRef = 1
+	hint := Clamp{
+		value: Immediate(*big.NewInt(15)),
+		min:   Immediate(*big.NewInt(20)),
+		max:   Immediate(*big.NewInt(10)),
+		dst:   dst,
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "cannot be greater than max")
+}
+
+func TestStrLen(t *testing.T) {
+	testCases := []struct {
+		name     string
+		str      []int
+		expected uint64
+	}{
+		{"empty string", []int{0}, 0},
+		{"short string", []int{'c', 'a', 'i', 'r', 'o', 0}, 5},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			strSegment := vm.Memory.AllocateEmptySegment()
+			for i, c := range tc.str {
+				writeTo(vm, uint64(strSegment), uint64(i), memory.MemoryValueFromInt(c))
+			}
+			writeTo(vm, VM.ExecutionSegment, 0, memory.MemoryValueFromSegmentAndOffset(strSegment, 0))
+
+			var strRef ApCellRef = 0
+			var dst ApCellRef = 1
+			hint := StrLen{
+				str:       Deref{strRef},
+				maxLength: Immediate(*big.NewInt(10)),
+				dst:       dst,
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(t, memory.MemoryValueFromUint(tc.expected), readFrom(vm, VM.ExecutionSegment, 1))
+		})
+	}
+}
+
+func TestStrLenMaxLength(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	strSegment := vm.Memory.AllocateEmptySegment()
+	for i := 0; i < 5; i++ {
+		writeTo(vm, uint64(strSegment), uint64(i), memory.MemoryValueFromInt('a'))
+	}
+	writeTo(vm, uint64(strSegment), 5, memory.MemoryValueFromInt(0))
+	writeTo(vm, VM.ExecutionSegment, 0, memory.MemoryValueFromSegmentAndOffset(strSegment, 0))
+
+	var strRef ApCellRef = 0
+	var dst ApCellRef = 1
+	hint := StrLen{
+		str:       Deref{strRef},
+		maxLength: Immediate(*big.NewInt(4)),
+		dst:       dst,
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "no terminator found within 4 cells")
+}
+
+func TestModConst(t *testing.T) {
+	largeValue, _ := new(big.Int).SetString("800000000000011000000000000000000000000000000000000000000000000", 16)
+
+	testCases := []struct {
+		value    *big.Int
+		constant int64
+		expected uint64
+	}{
+		{big.NewInt(17), 5, 2},
+		{big.NewInt(20), 5, 0},
+		{big.NewInt(3), 7, 3},
+		{big.NewInt(1 << 40), 3, 1},
+		// p - 1 = 2**251 + 17 * 2**192
+		{largeValue, 2, 0},
+		{largeValue, 3, 1},
+		{largeValue, 10, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s mod %d", tc.value, tc.constant), func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			var dst ApCellRef = 1
+			hint := ModConst{
+				value:    Immediate(*tc.value),
+				constant: Immediate(*big.NewInt(tc.constant)),
+				dst:      dst,
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(t, memory.MemoryValueFromUint(tc.expected), readFrom(vm, VM.ExecutionSegment, 1))
+		})
+	}
+}
+
+func TestModConstByZero(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	var dst ApCellRef = 1
+	hint := ModConst{
+		value:    Immediate(*big.NewInt(17)),
+		constant: Immediate(*big.NewInt(0)),
+		dst:      dst,
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "cannot divide by zero")
+}
+
+func TestAssertSumEq(t *testing.T) {
+	testCases := []struct {
+		name        string
+		expected    int64
+		expectedErr string
+	}{
+		{"correct sum", 60, ""},
+		{"off by one", 61, "sum 60 is different from the expected value 61"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			writeTo(vm, VM.ExecutionSegment, 0, writeSegment(
+				vm,
+				memory.MemoryValueFromInt(10),
+				memory.MemoryValueFromInt(20),
+				memory.MemoryValueFromInt(30),
+			))
+
+			var startRef ApCellRef = 0
+			hint := AssertSumEq{
+				start:    Deref{startRef},
+				length:   Immediate(*big.NewInt(3)),
+				expected: Immediate(*big.NewInt(tc.expected)),
+			}
+
+			err := hint.Execute(vm, nil)
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestMaxMin(t *testing.T) {
+	testCases := []struct {
+		lhs, rhs    int64
+		expectedMax int64
+		expectedMin int64
+	}{
+		{3, 8, 8, 3},
+		{8, 3, 8, 3},
+		{5, 5, 5, 5},
+		// -1 is p - 1 when interpreted as a field integer
+		{-1, 7, -1, 7},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%d and %d", tc.lhs, tc.rhs), func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			var maxDst ApCellRef = 1
+			var minDst ApCellRef = 2
+			lhs := Immediate(*big.NewInt(tc.lhs))
+			rhs := Immediate(*big.NewInt(tc.rhs))
+
+			err := Max{lhs: lhs, rhs: rhs, dst: maxDst}.Execute(vm, nil)
+			require.NoError(t, err)
+			err = Min{lhs: lhs, rhs: rhs, dst: minDst}.Execute(vm, nil)
+			require.NoError(t, err)
+
+			require.Equal(t, memory.MemoryValueFromInt(tc.expectedMax), readFrom(vm, VM.ExecutionSegment, 1))
+			require.Equal(t, memory.MemoryValueFromInt(tc.expectedMin), readFrom(vm, VM.ExecutionSegment, 2))
+		})
+	}
+}
+
+func TestAssertBuiltinSegment(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	rangeCheckSegment := vm.Memory.AllocateBuiltinSegment(&builtins.RangeCheck{})
+	writeTo(vm, VM.ExecutionSegment, 0, memory.MemoryValueFromSegmentAndOffset(rangeCheckSegment, 0))
+	writeTo(vm, VM.ExecutionSegment, 1, memory.MemoryValueFromSegmentAndOffset(VM.ExecutionSegment, 0))
+
+	var correctRef ApCellRef = 0
+	var incorrectRef ApCellRef = 1
+
+	hint := AssertBuiltinSegment{
+		ptr:     Deref{correctRef},
+		builtin: builtins.RangeCheckName,
+	}
+	err := hint.Execute(vm, nil)
+	require.NoError(t, err)
+
+	hint = AssertBuiltinSegment{
+		ptr:     Deref{incorrectRef},
+		builtin: builtins.RangeCheckName,
+	}
+	err = hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "does not belong to the range_check builtin segment")
+}
+
+func TestGetBit(t *testing.T) {
+	// 2**251 + 5
+	value := new(big.Int).Lsh(big.NewInt(1), 251)
+	value.Add(value, big.NewInt(5))
+
+	testCases := []struct {
+		index    int64
+		expected uint64
+	}{
+		{0, 1},
+		{1, 0},
+		{2, 1},
+		{250, 0},
+		{251, 1},
+		{252, 0},
+		{1000, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("index %d", tc.index), func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			var dst ApCellRef = 1
+			hint := GetBit{
+				value: Immediate(*value),
+				index: Immediate(*big.NewInt(tc.index)),
+				dst:   dst,
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(t, memory.MemoryValueFromUint(tc.expected), readFrom(vm, VM.ExecutionSegment, 1))
+		})
+	}
+}
+
+func TestSetBit(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    int64
+		index    int64
+		flag     int64
+		expected uint64
+	}{
+		{"setting a clear bit", 0b1010, 0, 1, 0b1011},
+		{"clearing a set bit", 0b1010, 3, 0, 0b0010},
+		{"setting a set bit", 0b1010, 1, 1, 0b1010},
+		{"clearing a clear bit", 0b1010, 2, 0, 0b1010},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			var dst ApCellRef = 1
+			hint := SetBit{
+				value: Immediate(*big.NewInt(tc.value)),
+				index: Immediate(*big.NewInt(tc.index)),
+				flag:  Immediate(*big.NewInt(tc.flag)),
+				dst:   dst,
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(t, memory.MemoryValueFromUint(tc.expected), readFrom(vm, VM.ExecutionSegment, 1))
+		})
+	}
+}
+
+func TestSetBitInvalidIndex(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	var dst ApCellRef = 1
+	hint := SetBit{
+		value: Immediate(*big.NewInt(1)),
+		index: Immediate(*big.NewInt(252)),
+		flag:  Immediate(*big.NewInt(1)),
+		dst:   dst,
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "index 252 should be smaller than 252")
+}
+
+func TestAssertStarknetAddress(t *testing.T) {
+	testCases := []struct {
+		name        string
+		value       *big.Int
+		expectedErr string
+	}{
+		{"zero", big.NewInt(0), ""},
+		{"last valid address", new(big.Int).Sub(starknetAddressBound, big.NewInt(1)), ""},
+		{"boundary", new(big.Int).Set(starknetAddressBound), "is not a valid StarkNet address"},
+		{"above boundary", new(big.Int).Lsh(big.NewInt(1), 251), "is not a valid StarkNet address"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+			hint := AssertStarknetAddress{
+				value: Immediate(*tc.value),
+			}
+
+			err := hint.Execute(vm, nil)
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestToBaseN(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    int64
+		base     int64
+		expected []int
+	}{
+		{"base 10", 90210, 10, []int{0, 1, 2, 0, 9}},
+		{"base 256", 0x01020304, 256, []int{4, 3, 2, 1}},
+		{"zero", 0, 10, []int{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			digitsSegment := vm.Memory.AllocateEmptySegment()
+			writeTo(vm, VM.ExecutionSegment, 0, memory.MemoryValueFromSegmentAndOffset(digitsSegment, 0))
+
+			var digitsRef ApCellRef = 0
+			var length ApCellRef = 1
+			hint := ToBaseN{
+				value:  Immediate(*big.NewInt(tc.value)),
+				base:   Immediate(*big.NewInt(tc.base)),
+				digits: Deref{digitsRef},
+				length: length,
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(t, memory.MemoryValueFromInt(len(tc.expected)), readFrom(vm, VM.ExecutionSegment, 1))
+			for i, digit := range tc.expected {
+				require.Equal(t, memory.MemoryValueFromInt(digit), readFrom(vm, uint64(digitsSegment), uint64(i)))
+			}
+		})
+	}
+}
+
+func TestToBaseNInvalidBase(t *testing.T) {
+	vm := defaultVirtualMachine()
+	writeTo(vm, VM.ExecutionSegment, 0, memory.MemoryValueFromSegmentAndOffset(VM.ExecutionSegment, 5))
+
+	var digitsRef ApCellRef = 0
+	var length ApCellRef = 1
+	hint := ToBaseN{
+		value:  Immediate(*big.NewInt(10)),
+		base:   Immediate(*big.NewInt(1)),
+		digits: Deref{digitsRef},
+		length: length,
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "base 1 should be at least 2")
+}
+
+func TestFromBaseNRoundTrip(t *testing.T) {
+	value, _ := new(big.Int).SetString("3d937c035c878245caf64531a5756109c53068da139362728feb561405371cb", 16)
+
+	for _, base := range []int64{2, 10, 256} {
+		t.Run(fmt.Sprintf("base %d", base), func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			digitsSegment := vm.Memory.AllocateEmptySegment()
+			writeTo(vm, VM.ExecutionSegment, 0, memory.MemoryValueFromSegmentAndOffset(digitsSegment, 0))
+
+			var digitsRef ApCellRef = 0
+			var length ApCellRef = 1
+			var dst ApCellRef = 2
+			toBaseN := ToBaseN{
+				value:  Immediate(*value),
+				base:   Immediate(*big.NewInt(base)),
+				digits: Deref{digitsRef},
+				length: length,
+			}
+			err := toBaseN.Execute(vm, nil)
+			require.NoError(t, err)
+
+			fromBaseN := FromBaseN{
+				digits: Deref{digitsRef},
+				base:   Immediate(*big.NewInt(base)),
+				length: Deref{length},
+				dst:    dst,
+			}
+			err = fromBaseN.Execute(vm, nil)
+			require.NoError(t, err)
+
+			expected := new(f.Element).SetBigInt(value)
+			require.Equal(t, memory.MemoryValueFromFieldElement(expected), readFrom(vm, VM.ExecutionSegment, 2))
+		})
+	}
+}
+
+func TestFromBaseNInvalidDigit(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	writeTo(vm, VM.ExecutionSegment, 0, writeSegment(
+		vm,
+		memory.MemoryValueFromInt(3),
+		memory.MemoryValueFromInt(10),
+	))
+
+	var digitsRef ApCellRef = 0
+	var dst ApCellRef = 1
+	hint := FromBaseN{
+		digits: Deref{digitsRef},
+		base:   Immediate(*big.NewInt(10)),
+		length: Immediate(*big.NewInt(2)),
+		dst:    dst,
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "digit 10 at position 1 is not smaller than base 10")
+}
+
+func TestFromBaseNOverflow(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	digits := make([]memory.MemoryValue, 32)
+	for i := range digits {
+		digits[i] = memory.MemoryValueFromInt(255)
+	}
+	writeTo(vm, VM.ExecutionSegment, 0, writeSegment(vm, digits...))
+
+	var digitsRef ApCellRef = 0
+	var dst ApCellRef = 1
+	hint := FromBaseN{
+		digits: Deref{digitsRef},
+		base:   Immediate(*big.NewInt(256)),
+		length: Immediate(*big.NewInt(32)),
+		dst:    dst,
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "exceeds the field modulus")
+}
+
+func TestSaturatingAdd(t *testing.T) {
+	testCases := []struct {
+		name          string
+		value, addend int64
+		expected      uint64
+	}{
+		{"non saturating", 100, 55, 155},
+		{"saturating", 200, 100, 255},
+		{"sum equals bound", 200, 55, 255},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			var dst ApCellRef = 1
+			hint := SaturatingAdd{
+				value:  Immediate(*big.NewInt(tc.value)),
+				addend: Immediate(*big.NewInt(tc.addend)),
+				bound:  Immediate(*big.NewInt(255)),
+				dst:    dst,
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(t, memory.MemoryValueFromUint(tc.expected), readFrom(vm, VM.ExecutionSegment, 1))
+		})
+	}
+}
+
+func TestSaturatingSub(t *testing.T) {
+	testCases := []struct {
+		name              string
+		value, subtrahend int64
+		expected          uint64
+	}{
+		{"value greater than subtrahend", 10, 3, 7},
+		{"value smaller than subtrahend", 3, 10, 0},
+		{"value equals subtrahend", 5, 5, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			var dst ApCellRef = 1
+			hint := SaturatingSub{
+				value:      Immediate(*big.NewInt(tc.value)),
+				subtrahend: Immediate(*big.NewInt(tc.subtrahend)),
+				dst:        dst,
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(t, memory.MemoryValueFromUint(tc.expected), readFrom(vm, VM.ExecutionSegment, 1))
+		})
+	}
+}
+
+func TestTrailingZeros(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    *big.Int
+		expected uint64
+	}{
+		{"odd value", big.NewInt(13), 0},
+		{"power of two", new(big.Int).Lsh(big.NewInt(1), 200), 200},
+		{"even value", big.NewInt(24), 3},
+		{"zero", big.NewInt(0), 252},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			var dst ApCellRef = 1
+			hint := TrailingZeros{
+				value: Immediate(*tc.value),
+				dst:   dst,
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(t, memory.MemoryValueFromUint(tc.expected), readFrom(vm, VM.ExecutionSegment, 1))
+		})
+	}
+}
+
+func TestAssertAllBool(t *testing.T) {
+	testCases := []struct {
+		name        string
+		values      []int
+		expectedErr string
+	}{
+		{"all booleans", []int{0, 1, 1, 0}, ""},
+		{"non boolean value", []int{1, 0, 2, 1}, "value 2 at index 2 is not a boolean"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			values := intValues(tc.values...)
+			writeTo(vm, VM.ExecutionSegment, 0, writeSegment(vm, values...))
+
+			var startRef ApCellRef = 0
+			hint := AssertAllBool{
+				start:  Deref{startRef},
+				length: Immediate(*big.NewInt(int64(len(tc.values)))),
+			}
+
+			err := hint.Execute(vm, nil)
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestRotateLeft128(t *testing.T) {
+	// 0x8000000000000000_0000000000000001
+	value := new(big.Int).Lsh(big.NewInt(1), 127)
+	value.Or(value, big.NewInt(1))
+
+	testCases := []struct {
+		amount   int64
+		expected *big.Int
+	}{
+		{0, value},
+		// the top bit is carried back to the right
+		{1, big.NewInt(3)},
+		// the two 64 bits halves are swapped
+		{64, new(big.Int).Or(new(big.Int).Lsh(big.NewInt(1), 64), new(big.Int).Lsh(big.NewInt(1), 63))},
+		{128, value},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("rotate by %d", tc.amount), func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			var dst ApCellRef = 1
+			hint := RotateLeft128{
+				value:  Immediate(*value),
+				amount: Immediate(*big.NewInt(tc.amount)),
+				dst:    dst,
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+
+			expected := f.Element{}
+			expected.SetBigInt(tc.expected)
+			require.Equal(t, memory.MemoryValueFromFieldElement(&expected), readFrom(vm, VM.ExecutionSegment, 1))
+		})
+	}
+}
+
+func TestRotateLeft128NotU128(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	var dst ApCellRef = 1
+	hint := RotateLeft128{
+		value:  Immediate(*new(big.Int).Lsh(big.NewInt(1), 128)),
+		amount: Immediate(*big.NewInt(1)),
+		dst:    dst,
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "should be u128")
+}
+
+func TestMsb(t *testing.T) {
+	testCases := []struct {
+		name          string
+		value         *big.Int
+		expectedIndex uint64
+		expectedFlag  uint64
+	}{
+		{"zero", big.NewInt(0), 0, 1},
+		{"one", big.NewInt(1), 0, 0},
+		{"small value", big.NewInt(0b1011), 3, 0},
+		{"large value", new(big.Int).Lsh(big.NewInt(3), 249), 250, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			var dst ApCellRef = 1
+			var zeroFlag ApCellRef = 2
+			hint := Msb{
+				value:    Immediate(*tc.value),
+				dst:      dst,
+				zeroFlag: zeroFlag,
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(t, memory.MemoryValueFromUint(tc.expectedIndex), readFrom(vm, VM.ExecutionSegment, 1))
+			require.Equal(t, memory.MemoryValueFromUint(tc.expectedFlag), readFrom(vm, VM.ExecutionSegment, 2))
+		})
+	}
+}
+
+func TestDotProduct(t *testing.T) {
+	testCases := []struct {
+		name     string
+		lhs, rhs []int
+		expected int64
+	}{
+		{"small vectors", []int{1, 2, 3}, []int{4, 5, 6}, 32},
+		{"negative values", []int{-1, 2}, []int{3, 4}, 5},
+		{"empty vectors", []int{}, []int{}, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			lhs := make([]memory.MemoryValue, len(tc.lhs))
+			rhs := make([]memory.MemoryValue, len(tc.rhs))
+			for i := range tc.lhs {
+				lhs[i] = memory.MemoryValueFromInt(tc.lhs[i])
+				rhs[i] = memory.MemoryValueFromInt(tc.rhs[i])
+			}
+			writeTo(vm, VM.ExecutionSegment, 0, writeSegment(vm, lhs...))
+			writeTo(vm, VM.ExecutionSegment, 1, writeSegment(vm, rhs...))
+
+			var lhsRef ApCellRef = 0
+			var rhsRef ApCellRef = 1
+			var dst ApCellRef = 2
+			hint := DotProduct{
+				lhs:    Deref{lhsRef},
+				rhs:    Deref{rhsRef},
+				length: Immediate(*big.NewInt(int64(len(tc.lhs)))),
+				dst:    dst,
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(t, memory.MemoryValueFromInt(tc.expected), readFrom(vm, VM.ExecutionSegment, 2))
+		})
+	}
+}
+
+func TestAssertAccessDeltasNonNegative(t *testing.T) {
+	testCases := []struct {
+		name          string
+		accessIndices []int
+		expectedErr   string
+	}{
+		{"valid accesses", []int{0, 3, 3, 7}, ""},
+		{"single access", []int{5}, ""},
+		{
+			"corrupted accesses",
+			[]int{0, 4, 2, 7},
+			"negative delta at index 2: access index 2 is smaller than the previous one 4",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			accessIndices := make([]memory.MemoryValue, len(tc.accessIndices))
+			for i, index := range tc.accessIndices {
+				accessIndices[i] = memory.MemoryValueFromInt(index)
+			}
+			writeTo(vm, VM.ExecutionSegment, 0, writeSegment(vm, accessIndices...))
+
+			var accessIndicesRef ApCellRef = 0
+			hint := AssertAccessDeltasNonNegative{
+				accessIndices: Deref{accessIndicesRef},
+				length:        Immediate(*big.NewInt(int64(len(tc.accessIndices)))),
+			}
+
+			err := hint.Execute(vm, nil)
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestLegendre(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    int64
+		expected int
+	}{
+		{"quadratic residue", 4, 1},
+		// 3 generates the multiplicative group of the stark field
+		{"quadratic non residue", 3, -1},
+		{"zero", 0, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			var dst ApCellRef = 1
+			hint := Legendre{
+				value: Immediate(*big.NewInt(tc.value)),
+				dst:   dst,
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(t, memory.MemoryValueFromInt(tc.expected), readFrom(vm, VM.ExecutionSegment, 1))
+		})
+	}
+}
+
+func TestCompressDecompressEcPoint(t *testing.T) {
+	_, generator := starkcurve.Generators()
+
+	for _, k := range []int64{1, 2, 5, 100} {
+		t.Run(fmt.Sprintf("k=%d", k), func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			point := starkcurve.G1Affine{}
+			point.ScalarMultiplication(&generator, big.NewInt(k))
+
+			var dstX ApCellRef = 1
+			var dstParity ApCellRef = 2
+			compress := CompressEcPoint{
+				x:         Immediate(*point.X.BigInt(new(big.Int))),
+				y:         Immediate(*point.Y.BigInt(new(big.Int))),
+				dstX:      dstX,
+				dstParity: dstParity,
+			}
+			err := compress.Execute(vm, nil)
+			require.NoError(t, err)
+
+			var dstY ApCellRef = 3
+			decompress := DecompressEcPoint{
+				x:      Deref{dstX},
+				parity: Deref{dstParity},
+				dstY:   dstY,
+			}
+			err = decompress.Execute(vm, nil)
+			require.NoError(t, err)
+
+			x := readFrom(vm, VM.ExecutionSegment, 1)
+			y := readFrom(vm, VM.ExecutionSegment, 3)
+			xFelt, err := x.FieldElement()
+			require.NoError(t, err)
+			yFelt, err := y.FieldElement()
+			require.NoError(t, err)
+
+			recovered := starkcurve.G1Affine{X: *xFelt, Y: *yFelt}
+			require.True(t, recovered.IsOnCurve())
+			require.Equal(t, point, recovered)
+		})
+	}
+}
+
+func TestDecompressEcPointNotOnCurve(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	// there is no point of the curve with x = 5, since 5^3 + 5 + b is a non residue
+	var dstY ApCellRef = 1
+	hint := DecompressEcPoint{
+		x:      Immediate(*big.NewInt(5)),
+		parity: Immediate(*big.NewInt(0)),
+		dstY:   dstY,
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "is not the coordinate of any point of the curve")
+}
+
+func TestAssertRangeCheckBudget(t *testing.T) {
+	testCases := []struct {
+		name        string
+		budget      int64
+		expectedErr string
+	}{
+		{"under budget", 5, ""},
+		{"exactly the budget", 3, ""},
+		{"over budget", 2, "3 range check instances used, exceeding the budget of 2"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			rangeCheckSegment := vm.Memory.AllocateBuiltinSegment(&builtins.RangeCheck{})
+			for i := 0; i < 3; i++ {
+				writeTo(vm, uint64(rangeCheckSegment), uint64(i), memory.MemoryValueFromInt(i))
+			}
+
+			hint := AssertRangeCheckBudget{
+				budget: Immediate(*big.NewInt(tc.budget)),
+			}
+
+			err := hint.Execute(vm, nil)
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	writeTo(vm, VM.ExecutionSegment, 0, writeSegment(
+		vm,
+		memory.MemoryValueFromInt(1),
+		memory.MemoryValueFromInt(2),
+		memory.MemoryValueFromInt(3),
+	))
+
+	var startRef ApCellRef = 0
+	var dst ApCellRef = 1
+	hint := Checksum{
+		start:  Deref{startRef},
+		length: Immediate(*big.NewInt(3)),
+		dst:    dst,
+	}
+
+	err := hint.Execute(vm, nil)
+	require.NoError(t, err)
+	// (1 * 31 + 2) * 31 + 3
+	require.Equal(t, memory.MemoryValueFromInt(1026), readFrom(vm, VM.ExecutionSegment, 1))
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	testCases := []struct {
+		name        string
+		expected    int64
+		expectedErr string
+	}{
+		{"matching checksum", 1026, ""},
+		{"mismatching checksum", 1027, "checksum 1026 is different from the expected checksum 1027"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			writeTo(vm, VM.ExecutionSegment, 0, writeSegment(
+				vm,
+				memory.MemoryValueFromInt(1),
+				memory.MemoryValueFromInt(2),
+				memory.MemoryValueFromInt(3),
+			))
+
+			var startRef ApCellRef = 0
+			hint := VerifyChecksum{
+				start:    Deref{startRef},
+				length:   Immediate(*big.NewInt(3)),
+				expected: Immediate(*big.NewInt(tc.expected)),
+			}
+
+			err := hint.Execute(vm, nil)
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestAlloc2DArray(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	var dst ApCellRef = 0
+	hint := Alloc2DArray{
+		rows:    Immediate(*big.NewInt(2)),
+		columns: Immediate(*big.NewInt(3)),
+		dst:     dst,
+	}
+
+	err := hint.Execute(vm, nil)
+	require.NoError(t, err)
+
+	// segment 2 is the backing segment and segment 3 holds the row pointers
+	require.Equal(t, memory.MemoryValueFromSegmentAndOffset(3, 0), readFrom(vm, VM.ExecutionSegment, 0))
+	require.Equal(t, memory.MemoryValueFromSegmentAndOffset(2, 0), readFrom(vm, 3, 0))
+	require.Equal(t, memory.MemoryValueFromSegmentAndOffset(2, 3), readFrom(vm, 3, 1))
+	require.Equal(t, uint64(2), vm.Memory.Segments[3].Len())
+}
+
+func TestTransposeLayout(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	var matrix ApCellRef = 0
+	rows := Immediate(*big.NewInt(2))
+	columns := Immediate(*big.NewInt(3))
+	err := Alloc2DArray{rows: rows, columns: columns, dst: matrix}.Execute(vm, nil)
+	require.NoError(t, err)
+
+	var dst ApCellRef = 1
+	hint := TransposeLayout{
+		rowPtrs: Deref{matrix},
+		rows:    rows,
+		columns: columns,
+		dst:     dst,
+	}
+	err = hint.Execute(vm, nil)
+	require.NoError(t, err)
+
+	// segment 2 backs the 2x3 matrix, segment 3 holds its row pointers and
+	// segment 4 the transposed 3x2 layout
+	require.Equal(t, memory.MemoryValueFromSegmentAndOffset(4, 0), readFrom(vm, VM.ExecutionSegment, 1))
+	require.Equal(t, uint64(6), vm.Memory.Segments[4].Len())
+	// transposed (0, 0) is (0, 0)
+	require.Equal(t, memory.MemoryValueFromSegmentAndOffset(2, 0), readFrom(vm, 4, 0))
+	// transposed (0, 1) is (1, 0)
+	require.Equal(t, memory.MemoryValueFromSegmentAndOffset(2, 3), readFrom(vm, 4, 1))
+	// transposed (1, 0) is (0, 1)
+	require.Equal(t, memory.MemoryValueFromSegmentAndOffset(2, 1), readFrom(vm, 4, 2))
+	// transposed (2, 1) is (1, 2)
+	require.Equal(t, memory.MemoryValueFromSegmentAndOffset(2, 5), readFrom(vm, 4, 5))
+}
+
+func TestFillRecurrence(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	writeTo(vm, VM.ExecutionSegment, 0, writeSegment(vm))
+
+	var startRef ApCellRef = 0
+	hint := FillRecurrence{
+		start:  Deref{startRef},
+		length: Immediate(*big.NewInt(10)),
+		first:  Immediate(*big.NewInt(0)),
+		second: Immediate(*big.NewInt(1)),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.NoError(t, err)
+
+	expected := []uint64{0, 1, 1, 2, 3, 5, 8, 13, 21, 34}
+	require.Equal(t, uint64(len(expected)), vm.Memory.Segments[2].Len())
+	for i := range expected {
+		require.Equal(t, memory.MemoryValueFromUint(expected[i]), readFrom(vm, 2, uint64(i)))
+	}
+}
+
+func TestInverse(t *testing.T) {
+	for _, value := range []int64{1, 2, 12345, -7} {
+		t.Run(fmt.Sprintf("inverse of %d", value), func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			var dst ApCellRef = 1
+			hint := Inverse{
+				value: Immediate(*big.NewInt(value)),
+				dst:   dst,
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+
+			inverse := readFrom(vm, VM.ExecutionSegment, 1)
+			inverseFelt, err := inverse.FieldElement()
+			require.NoError(t, err)
+
+			valueFelt := f.Element{}
+			valueFelt.SetBigInt(big.NewInt(value))
+			product := f.Element{}
+			product.Mul(&valueFelt, inverseFelt)
+			require.True(t, product.IsOne())
+		})
+	}
+}
+
+func TestInverseZero(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	var dst ApCellRef = 1
+	hint := Inverse{
+		value: Immediate(*big.NewInt(0)),
+		dst:   dst,
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "cannot invert zero")
+}
+
+func TestReadDoubleDeref(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	writeTo(vm, VM.ExecutionSegment, 0, writeSegment(
+		vm,
+		memory.MemoryValueFromInt(10),
+		memory.MemoryValueFromInt(20),
+	))
+
+	var ptr ApCellRef = 0
+	var dst ApCellRef = 1
+	hint := ReadDoubleDeref{
+		ptr:    ptr,
+		offset: 1,
+		dst:    dst,
+	}
+
+	err := hint.Execute(vm, nil)
+	require.NoError(t, err)
+	require.Equal(t, memory.MemoryValueFromInt(20), readFrom(vm, VM.ExecutionSegment, 1))
+}
+
+func TestReadDoubleDerefErrors(t *testing.T) {
+	t.Run("intermediate value is not a pointer", func(t *testing.T) {
+		vm := defaultVirtualMachine()
+
+		writeTo(vm, VM.ExecutionSegment, 0, memory.MemoryValueFromInt(7))
+
+		var ptr ApCellRef = 0
+		var dst ApCellRef = 1
+		hint := ReadDoubleDeref{ptr: ptr, offset: 0, dst: dst}
+
+		err := hint.Execute(vm, nil)
+		require.ErrorContains(t, err, "memory value is not an address")
+	})
+
+	t.Run("final cell is unwritten", func(t *testing.T) {
+		vm := defaultVirtualMachine()
+
+		writeTo(vm, VM.ExecutionSegment, 0, writeSegment(vm, memory.MemoryValueFromInt(10)))
+
+		var ptr ApCellRef = 0
+		var dst ApCellRef = 1
+		hint := ReadDoubleDeref{ptr: ptr, offset: 3, dst: dst}
+
+		err := hint.Execute(vm, nil)
+		require.ErrorContains(t, err, "reading unknown value")
+	})
+}
+
+func TestAssertAllEq(t *testing.T) {
+	testCases := []struct {
+		name        string
+		values      []int
+		expectedErr string
+	}{
+		{"uniform range", []int{9, 9, 9, 9}, ""},
+		{"single differing cell", []int{9, 9, 8, 9}, "value 8 at index 2 is different from 9"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			values := intValues(tc.values...)
+			writeTo(vm, VM.ExecutionSegment, 0, writeSegment(vm, values...))
+
+			var startRef ApCellRef = 0
+			hint := AssertAllEq{
+				start:    Deref{startRef},
+				length:   Immediate(*big.NewInt(int64(len(tc.values)))),
+				constant: Immediate(*big.NewInt(9)),
+			}
+
+			err := hint.Execute(vm, nil)
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestRunningMax(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	src := []int{3, 1, 4, 1, 5, 9, 2, 6}
+	values := intValues(src...)
+	writeTo(vm, VM.ExecutionSegment, 0, writeSegment(vm, values...))
+	writeTo(vm, VM.ExecutionSegment, 1, writeSegment(vm))
+
+	var srcRef ApCellRef = 0
+	var dstRef ApCellRef = 1
+	hint := RunningMax{
+		src:    Deref{srcRef},
+		dst:    Deref{dstRef},
+		length: Immediate(*big.NewInt(int64(len(src)))),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.NoError(t, err)
+
+	expected := []int{3, 3, 4, 4, 5, 9, 9, 9}
+	require.Equal(t, uint64(len(expected)), vm.Memory.Segments[3].Len())
+	for i := range expected {
+		require.Equal(t, memory.MemoryValueFromInt(expected[i]), readFrom(vm, 3, uint64(i)))
+	}
+}
+
+func TestAssertEthAddress(t *testing.T) {
+	testCases := []struct {
+		name        string
+		value       *big.Int
+		expectedErr string
+	}{
+		{"zero", big.NewInt(0), ""},
+		{"last valid address", new(big.Int).Sub(ethAddressBound, big.NewInt(1)), ""},
+		{"boundary", new(big.Int).Set(ethAddressBound), "is not a valid Ethereum address"},
+		{"above boundary", new(big.Int).Lsh(big.NewInt(1), 200), "is not a valid Ethereum address"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+			hint := AssertEthAddress{
+				value: Immediate(*tc.value),
+			}
+
+			err := hint.Execute(vm, nil)
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestKeccakPackedUint256(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	// uint256(1) and uint256(2) as (low, high) pairs
+	writeTo(vm, VM.ExecutionSegment, 0, writeSegment(
+		vm,
+		memory.MemoryValueFromInt(1),
+		memory.MemoryValueFromInt(0),
+		memory.MemoryValueFromInt(2),
+		memory.MemoryValueFromInt(0),
+	))
+
+	var wordsRef ApCellRef = 0
+	var low ApCellRef = 1
+	var high ApCellRef = 2
+	hint := KeccakPackedUint256{
+		words:  Deref{wordsRef},
+		length: Immediate(*big.NewInt(2)),
+		low:    low,
+		high:   high,
+	}
+
+	err := hint.Execute(vm, nil)
+	require.NoError(t, err)
+
+	// keccak256(abi.encodePacked(uint256(1), uint256(2)))
+	expectedLow, _ := new(f.Element).SetString("0xc83a08bbccc01a0644d599ccd2a7c2e0")
+	expectedHigh, _ := new(f.Element).SetString("0xe90b7bceb6e7df5418fb78d8ee546e97")
+	require.Equal(t, memory.MemoryValueFromFieldElement(expectedLow), readFrom(vm, VM.ExecutionSegment, 1))
+	require.Equal(t, memory.MemoryValueFromFieldElement(expectedHigh), readFrom(vm, VM.ExecutionSegment, 2))
+}
+
+func TestAssertNeq(t *testing.T) {
+	testCases := []struct {
+		name        string
+		lhs, rhs    *big.Int
+		expectedErr string
+	}{
+		{"distinct values", big.NewInt(3), big.NewInt(4), ""},
+		{"equal values", big.NewInt(3), big.NewInt(3), "lhs 3 and rhs 3 are equal"},
+		{"same residue", new(big.Int).Add(f.Modulus(), big.NewInt(3)), big.NewInt(3), "lhs 3 and rhs 3 are equal"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+			hint := AssertNeq{
+				lhs: Immediate(*tc.lhs),
+				rhs: Immediate(*tc.rhs),
+			}
+
+			err := hint.Execute(vm, nil)
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestAssertNeqAddresses(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	writeTo(vm, VM.ExecutionSegment, 0, memory.MemoryValueFromSegmentAndOffset(2, 1))
+	writeTo(vm, VM.ExecutionSegment, 1, memory.MemoryValueFromSegmentAndOffset(2, 1))
+	writeTo(vm, VM.ExecutionSegment, 2, memory.MemoryValueFromSegmentAndOffset(3, 1))
+
+	var first ApCellRef = 0
+	var second ApCellRef = 1
+	var third ApCellRef = 2
+
+	err := AssertNeq{lhs: Deref{first}, rhs: Deref{third}}.Execute(vm, nil)
+	require.NoError(t, err)
+
+	err = AssertNeq{lhs: Deref{first}, rhs: Deref{second}}.Execute(vm, nil)
+	require.ErrorContains(t, err, "are equal")
+}
+
+func TestTwoAdicValuation(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    *big.Int
+		expected uint64
+	}{
+		{"odd value", big.NewInt(21), 0},
+		{"even value", big.NewInt(12), 2},
+		{"highly divisible value", new(big.Int).Lsh(big.NewInt(3), 192), 192},
+		{"zero", big.NewInt(0), 252},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			var dst ApCellRef = 1
+			hint := TwoAdicValuation{
+				value: Immediate(*tc.value),
+				dst:   dst,
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(t, memory.MemoryValueFromUint(tc.expected), readFrom(vm, VM.ExecutionSegment, 1))
+		})
+	}
+}
+
+func TestAssertIdentityMatrix(t *testing.T) {
+	testCases := []struct {
+		name        string
+		matrix      [][]int
+		expectedErr string
+	}{
+		{"identity", [][]int{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}, ""},
+		{"perturbed diagonal", [][]int{{1, 0, 0}, {0, 2, 0}, {0, 0, 1}}, "diagonal element (1, 1) is 2 instead of 1"},
+		{"perturbed off diagonal", [][]int{{1, 0, 0}, {0, 1, 0}, {0, 5, 1}}, "element (2, 1) is 5 instead of 0"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			rowPtrs := make([]memory.MemoryValue, len(tc.matrix))
+			for i, row := range tc.matrix {
+				values := intValues(row...)
+				rowPtrs[i] = writeSegment(vm, values...)
+			}
+			writeTo(vm, VM.ExecutionSegment, 0, writeSegment(vm, rowPtrs...))
+
+			var rowPtrsRef ApCellRef = 0
+			hint := AssertIdentityMatrix{
+				rowPtrs:   Deref{rowPtrsRef},
+				dimension: Immediate(*big.NewInt(int64(len(tc.matrix)))),
+			}
+
+			err := hint.Execute(vm, nil)
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestPrefixSum(t *testing.T) {
+	testCases := []struct {
+		name     string
+		src      []int
+		expected []int
+	}{
+		{"small sequence", []int{1, 2, 3, -4, 10}, []int{1, 3, 6, 2, 12}},
+		{"empty sequence", []int{}, []int{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			ranges := writeRanges(vm, intValues(tc.src...), nil)
+			hint := PrefixSum{
+				src:    ranges[0],
+				dst:    ranges[1],
+				length: Immediate(*big.NewInt(int64(len(tc.src)))),
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+
+			require.Equal(t, uint64(len(tc.expected)), vm.Memory.Segments[3].Len())
+			for i := range tc.expected {
+				require.Equal(t, memory.MemoryValueFromInt(tc.expected[i]), readFrom(vm, 3, uint64(i)))
+			}
+		})
+	}
+}
+
+func TestAssertDictSquashed(t *testing.T) {
+	vm := defaultVirtualMachine()
+	ctx := NewHintRunnerContext()
+
+	dictAddr := ctx.DictionaryManager.NewDictionary(vm, memory.MemoryValueFromInt(0))
+	writeTo(vm, VM.ExecutionSegment, 0, memory.MemoryValueFromMemoryAddress(&dictAddr))
+
+	var dictRef ApCellRef = 0
+	notSquashed := AssertDictSquashed{dictPtr: Deref{dictRef}, squashed: false}
+	squashed := AssertDictSquashed{dictPtr: Deref{dictRef}, squashed: true}
+
+	// before squashing
+	require.NoError(t, notSquashed.Execute(vm, ctx))
+	err := squashed.Execute(vm, ctx)
+	require.ErrorContains(t, err, "dictionary at 2:0 is used before being squashed")
+
+	dict, err := ctx.DictionaryManager.GetDictionary(&dictAddr)
+	require.NoError(t, err)
+	require.NoError(t, dict.Squash())
+
+	// after squashing
+	require.NoError(t, squashed.Execute(vm, ctx))
+	err = notSquashed.Execute(vm, ctx)
+	require.ErrorContains(t, err, "dictionary at 2:0 is used after being squashed")
+}
+
+func TestAssertDictSquashedUnknownDict(t *testing.T) {
+	vm := defaultVirtualMachine()
+	ctx := NewHintRunnerContext()
+
+	hint := AssertDictSquashed{
+		dictPtr:  Immediate(*big.NewInt(0)),
+		squashed: false,
+	}
+	err := hint.Execute(vm, ctx)
+	require.ErrorContains(t, err, "memory value is not an address")
+
+	writeTo(vm, VM.ExecutionSegment, 0, writeSegment(vm))
+
+	var dictRef ApCellRef = 0
+	hint = AssertDictSquashed{dictPtr: Deref{dictRef}, squashed: false}
+	err = hint.Execute(vm, ctx)
+	require.ErrorContains(t, err, "no dictionary at segment 2")
+}
+
+func TestDivMod(t *testing.T) {
+	testCases := []struct {
+		name              string
+		lhs               *big.Int
+		rhs               *big.Int
+		expectedQuotient  *big.Int
+		expectedRemainder *big.Int
+		expectedErr       string
+	}{
+		{
+			name:              "exact division",
+			lhs:               big.NewInt(42),
+			rhs:               big.NewInt(7),
+			expectedQuotient:  big.NewInt(6),
+			expectedRemainder: big.NewInt(0),
+		},
+		{
+			name:              "division with remainder",
+			lhs:               big.NewInt(45),
+			rhs:               big.NewInt(7),
+			expectedQuotient:  big.NewInt(6),
+			expectedRemainder: big.NewInt(3),
+		},
+		{
+			name:              "u128 operands",
+			lhs:               new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1)),
+			rhs:               new(big.Int).Lsh(big.NewInt(1), 64),
+			expectedQuotient:  new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 64), big.NewInt(1)),
+			expectedRemainder: new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 64), big.NewInt(1)),
+		},
+		{
+			name:        "divide by zero",
+			lhs:         big.NewInt(42),
+			rhs:         big.NewInt(0),
+			expectedErr: "cannot divide by zero",
+		},
+		{
+			name:        "lhs out of range",
+			lhs:         new(big.Int).Lsh(big.NewInt(1), 128),
+			rhs:         big.NewInt(7),
+			expectedErr: "should be u128",
+		},
+		{
+			name:        "rhs out of range",
+			lhs:         big.NewInt(42),
+			rhs:         new(big.Int).Lsh(big.NewInt(1), 128),
+			expectedErr: "should be u128",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			var quotient ApCellRef = 1
+			var remainder ApCellRef = 2
+
+			hint := DivMod{
+				lhs:       Immediate(*tc.lhs),
+				rhs:       Immediate(*tc.rhs),
+				quotient:  quotient,
+				remainder: remainder,
+			}
+
+			err := hint.Execute(vm, nil)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			expectedQuotient := &f.Element{}
+			expectedQuotient.SetBigInt(tc.expectedQuotient)
+			expectedRemainder := &f.Element{}
+			expectedRemainder.SetBigInt(tc.expectedRemainder)
+
+			require.Equal(
+				t,
+				memory.MemoryValueFromFieldElement(expectedQuotient),
+				readFrom(vm, VM.ExecutionSegment, 1),
+			)
+			require.Equal(
+				t,
+				memory.MemoryValueFromFieldElement(expectedRemainder),
+				readFrom(vm, VM.ExecutionSegment, 2),
+			)
+		})
+	}
+}
+
+func TestXorFold(t *testing.T) {
+	testCases := []struct {
+		name     string
+		values   []int
+		expected uint64
+	}{
+		{"few values", []int{0b1100, 0b1010, 0b0110}, 0b0000},
+		{"odd parity", []int{1, 2, 4, 8, 1}, 0b1110},
+		{"empty range", []int{}, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			values := intValues(tc.values...)
+			writeTo(vm, VM.ExecutionSegment, 0, writeSegment(vm, values...))
+
+			var startRef ApCellRef = 0
+			var dst ApCellRef = 1
+			hint := XorFold{
+				start:  Deref{startRef},
+				length: Immediate(*big.NewInt(int64(len(tc.values)))),
+				dst:    dst,
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(t, memory.MemoryValueFromUint(tc.expected), readFrom(vm, VM.ExecutionSegment, 1))
+		})
+	}
+}
+
+func TestXorFoldReduced(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	// 2**251 ^ 2**250 is larger than the prime
+	high := f.Element{}
+	high.SetBigInt(new(big.Int).Lsh(big.NewInt(1), 251))
+	low := f.Element{}
+	low.SetBigInt(new(big.Int).Lsh(big.NewInt(1), 250))
+	writeTo(vm, VM.ExecutionSegment, 0, writeSegment(
+		vm,
+		memory.MemoryValueFromFieldElement(&high),
+		memory.MemoryValueFromFieldElement(&low),
+	))
+
+	var startRef ApCellRef = 0
+	var dst ApCellRef = 1
+	hint := XorFold{
+		start:  Deref{startRef},
+		length: Immediate(*big.NewInt(2)),
+		dst:    dst,
+	}
+
+	err := hint.Execute(vm, nil)
+	require.NoError(t, err)
+
+	expected := f.Element{}
+	expected.Add(&high, &low)
+	require.Equal(t, memory.MemoryValueFromFieldElement(&expected), readFrom(vm, VM.ExecutionSegment, 1))
+}
+
+func TestUint256Add(t *testing.T) {
+	maxU128 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+
+	testCases := []struct {
+		name                     string
+		aLow, aHigh, bLow, bHigh *big.Int
+		low, high                *big.Int
+		carry                    uint64
+	}{
+		{
+			name: "no carry",
+			aLow: big.NewInt(5), aHigh: big.NewInt(1),
+			bLow: big.NewInt(7), bHigh: big.NewInt(2),
+			low: big.NewInt(12), high: big.NewInt(3),
+			carry: 0,
+		},
+		{
+			name: "carry from low into high",
+			aLow: maxU128, aHigh: big.NewInt(1),
+			bLow: big.NewInt(2), bHigh: big.NewInt(2),
+			low: big.NewInt(1), high: big.NewInt(4),
+			carry: 0,
+		},
+		{
+			name: "full overflow",
+			aLow: maxU128, aHigh: maxU128,
+			bLow: big.NewInt(3), bHigh: big.NewInt(0),
+			low: big.NewInt(2), high: big.NewInt(0),
+			carry: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			var low ApCellRef = 1
+			var high ApCellRef = 2
+			var carry ApCellRef = 3
+			hint := Uint256Add{
+				aLow:  Immediate(*tc.aLow),
+				aHigh: Immediate(*tc.aHigh),
+				bLow:  Immediate(*tc.bLow),
+				bHigh: Immediate(*tc.bHigh),
+				low:   low,
+				high:  high,
+				carry: carry,
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+
+			expectedLow := f.Element{}
+			expectedLow.SetBigInt(tc.low)
+			expectedHigh := f.Element{}
+			expectedHigh.SetBigInt(tc.high)
+			require.Equal(t, memory.MemoryValueFromFieldElement(&expectedLow), readFrom(vm, VM.ExecutionSegment, 1))
+			require.Equal(t, memory.MemoryValueFromFieldElement(&expectedHigh), readFrom(vm, VM.ExecutionSegment, 2))
+			require.Equal(t, memory.MemoryValueFromUint(tc.carry), readFrom(vm, VM.ExecutionSegment, 3))
+		})
+	}
+}
+
+func TestUint256AddIncorrectRange(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	var low ApCellRef = 1
+	var high ApCellRef = 2
+	var carry ApCellRef = 3
+	hint := Uint256Add{
+		aLow:  Immediate(*new(big.Int).Lsh(big.NewInt(1), 128)),
+		aHigh: Immediate(*big.NewInt(0)),
+		bLow:  Immediate(*big.NewInt(0)),
+		bHigh: Immediate(*big.NewInt(0)),
+		low:   low,
+		high:  high,
+		carry: carry,
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "should be u128")
+}
+
+func TestAssertPoseidonEq(t *testing.T) {
+	commitment, _ := new(big.Int).SetString("5d44a3decb2b2e0cc71071f7b802f45dd792d064f0fc7316c46514f70f9891a", 16)
+
+	testCases := []struct {
+		name        string
+		expected    *big.Int
+		expectedErr string
+	}{
+		{"correct commitment", commitment, ""},
+		{"tampered commitment", new(big.Int).Add(commitment, big.NewInt(1)), "is different from the expected value"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+			hint := AssertPoseidonEq{
+				x:        Immediate(*big.NewInt(1)),
+				y:        Immediate(*big.NewInt(2)),
+				expected: Immediate(*tc.expected),
+			}
+
+			err := hint.Execute(vm, nil)
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestUint256SquareRoot(t *testing.T) {
+	pow := func(exponent uint) *big.Int {
+		return new(big.Int).Lsh(big.NewInt(1), exponent)
+	}
+	minus := func(lhs *big.Int, rhs int64) *big.Int {
+		return new(big.Int).Sub(lhs, big.NewInt(rhs))
+	}
+
+	testCases := []struct {
+		name                         string
+		valueLow, valueHigh          *big.Int
+		sqrt0, sqrt1                 *big.Int
+		remainderLow, remainderHigh  *big.Int
+		sqrtMul2MinusRemainderGeU128 uint64
+	}{
+		{
+			name:     "perfect square",
+			valueLow: big.NewInt(144), valueHigh: big.NewInt(0),
+			sqrt0: big.NewInt(12), sqrt1: big.NewInt(0),
+			remainderLow: big.NewInt(0), remainderHigh: big.NewInt(0),
+		},
+		{
+			name:     "non perfect square",
+			valueLow: big.NewInt(150), valueHigh: big.NewInt(0),
+			sqrt0: big.NewInt(12), sqrt1: big.NewInt(0),
+			remainderLow: big.NewInt(6), remainderHigh: big.NewInt(0),
+		},
+		{
+			// (2**80)^2 = 2**160
+			name:     "large perfect square",
+			valueLow: big.NewInt(0), valueHigh: pow(32),
+			sqrt0: big.NewInt(0), sqrt1: pow(16),
+			remainderLow: big.NewInt(0), remainderHigh: big.NewInt(0),
+			sqrtMul2MinusRemainderGeU128: 0,
+		},
+		{
+			// (2**127)^2 + 2**127 leaves 2 * root - remainder = 2**127
+			name:     "flag unset",
+			valueLow: pow(127), valueHigh: pow(126),
+			sqrt0: big.NewInt(0), sqrt1: pow(63),
+			remainderLow: pow(127), remainderHigh: big.NewInt(0),
+			sqrtMul2MinusRemainderGeU128: 0,
+		},
+		{
+			// (2**127 + 1)^2 leaves 2 * root - remainder = 2**128 + 2
+			name:     "flag set",
+			valueLow: big.NewInt(1), valueHigh: new(big.Int).Add(pow(126), big.NewInt(1)),
+			sqrt0: big.NewInt(1), sqrt1: pow(63),
+			remainderLow: big.NewInt(0), remainderHigh: big.NewInt(0),
+			sqrtMul2MinusRemainderGeU128: 1,
+		},
+		{
+			// 2**256 - 1 = (2**128 - 1)^2 + 2**129 - 2
+			name:     "max u256",
+			valueLow: minus(pow(128), 1), valueHigh: minus(pow(128), 1),
+			sqrt0: minus(pow(64), 1), sqrt1: minus(pow(64), 1),
+			remainderLow: minus(pow(128), 2), remainderHigh: big.NewInt(1),
+			sqrtMul2MinusRemainderGeU128: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			var sqrt0 ApCellRef = 1
+			var sqrt1 ApCellRef = 2
+			var remainderLow ApCellRef = 3
+			var remainderHigh ApCellRef = 4
+			var flag ApCellRef = 5
+			hint := Uint256SquareRoot{
+				valueLow:                     Immediate(*tc.valueLow),
+				valueHigh:                    Immediate(*tc.valueHigh),
+				sqrt0:                        sqrt0,
+				sqrt1:                        sqrt1,
+				remainderLow:                 remainderLow,
+				remainderHigh:                remainderHigh,
+				sqrtMul2MinusRemainderGeU128: flag,
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+
+			for i, expected := range []*big.Int{tc.sqrt0, tc.sqrt1, tc.remainderLow, tc.remainderHigh} {
+				expectedFelt := f.Element{}
+				expectedFelt.SetBigInt(expected)
+				require.Equal(
+					t,
+					memory.MemoryValueFromFieldElement(&expectedFelt),
+					readFrom(vm, VM.ExecutionSegment, uint64(i+1)),
+					"cell %d", i+1,
+				)
+			}
+			require.Equal(
+				t,
+				memory.MemoryValueFromUint(tc.sqrtMul2MinusRemainderGeU128),
+				readFrom(vm, VM.ExecutionSegment, 5),
+			)
+		})
+	}
+}
+
+func TestBatchModReduce(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	src := []uint64{0, 6, 7, 13, 100, 1 << 40}
+	values := intValues(src...)
+	writeTo(vm, VM.ExecutionSegment, 0, writeSegment(vm, values...))
+	writeTo(vm, VM.ExecutionSegment, 1, writeSegment(vm))
+
+	var srcRef ApCellRef = 0
+	var dstRef ApCellRef = 1
+	hint := BatchModReduce{
+		src:     Deref{srcRef},
+		dst:     Deref{dstRef},
+		length:  Immediate(*big.NewInt(int64(len(src)))),
+		modulus: Immediate(*big.NewInt(7)),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.NoError(t, err)
+
+	require.Equal(t, uint64(len(src)), vm.Memory.Segments[3].Len())
+	for i, v := range src {
+		require.Equal(t, memory.MemoryValueFromUint(v%7), readFrom(vm, 3, uint64(i)))
+	}
+}
+
+func TestBatchModReduceNegativeValue(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	// -1 is p - 1, and p - 1 = 2^251 + 17 * 2^192 = 1 (mod 3)
+	writeTo(vm, VM.ExecutionSegment, 0, writeSegment(vm, memory.MemoryValueFromInt(-1)))
+	writeTo(vm, VM.ExecutionSegment, 1, writeSegment(vm))
+
+	var srcRef ApCellRef = 0
+	var dstRef ApCellRef = 1
+	hint := BatchModReduce{
+		src:     Deref{srcRef},
+		dst:     Deref{dstRef},
+		length:  Immediate(*big.NewInt(1)),
+		modulus: Immediate(*big.NewInt(3)),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.NoError(t, err)
+	require.Equal(t, memory.MemoryValueFromUint(uint64(1)), readFrom(vm, 3, 0))
+}
+
+func TestBatchModReduceZeroModulus(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	writeTo(vm, VM.ExecutionSegment, 0, writeSegment(vm, memory.MemoryValueFromInt(5)))
+	writeTo(vm, VM.ExecutionSegment, 1, writeSegment(vm))
+
+	var srcRef ApCellRef = 0
+	var dstRef ApCellRef = 1
+	hint := BatchModReduce{
+		src:     Deref{srcRef},
+		dst:     Deref{dstRef},
+		length:  Immediate(*big.NewInt(1)),
+		modulus: Immediate(*big.NewInt(0)),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "cannot divide by zero")
+}
+
+func TestAssertLeArcs(t *testing.T) {
+	fromString := func(value string) *big.Int {
+		result, _ := new(big.Int).SetString(value, 10)
+		return result
+	}
+	primeMinus := func(value int64) *big.Int {
+		return new(big.Int).Sub(f.Modulus(), big.NewInt(value))
+	}
+
+	testCases := []struct {
+		name        string
+		a, b        *big.Int
+		rangeChecks []*big.Int
+		skipA       uint64
+		skipBMinusA uint64
+	}{
+		{
+			// arcs are 1, 1 and p - 3, the last one is excluded
+			name:        "third arc excluded",
+			a:           big.NewInt(1),
+			b:           big.NewInt(2),
+			rangeChecks: []*big.Int{big.NewInt(1), big.NewInt(0), big.NewInt(1), big.NewInt(0)},
+			skipA:       1,
+			skipBMinusA: 1,
+		},
+		{
+			// arcs are 10, p - 12 and 1
+			name:        "second arc excluded",
+			a:           big.NewInt(10),
+			b:           primeMinus(2),
+			rangeChecks: []*big.Int{big.NewInt(1), big.NewInt(0), big.NewInt(10), big.NewInt(0)},
+			skipA:       1,
+			skipBMinusA: 0,
+		},
+		{
+			// arcs are p - 10, 5 and 4
+			name:        "first arc excluded",
+			a:           primeMinus(10),
+			b:           primeMinus(5),
+			rangeChecks: []*big.Int{big.NewInt(4), big.NewInt(0), big.NewInt(5), big.NewInt(0)},
+			skipA:       0,
+			skipBMinusA: 1,
+		},
+		{
+			// arcs are 2**200 and 2**250 - 2**200, both spanning the high part
+			name: "large arcs",
+			a:    new(big.Int).Lsh(big.NewInt(1), 200),
+			b:    new(big.Int).Lsh(big.NewInt(1), 250),
+			rangeChecks: []*big.Int{
+				fromString("1397216016019607668675575808"),
+				fromString("453347182355485927145472"),
+				fromString("5316911981696065729389820261099076673"),
+				fromString("340282366920938151196890927676487664575"),
+			},
+			skipA:       1,
+			skipBMinusA: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+			ctx := NewHintRunnerContext()
+
+			writeTo(vm, VM.ExecutionSegment, 0, writeSegment(vm))
+
+			var rangeCheckPtr ApCellRef = 0
+			findArcs := AssertLeFindSmallArcs{
+				rangeCheckPtr: Deref{rangeCheckPtr},
+				a:             Immediate(*tc.a),
+				b:             Immediate(*tc.b),
+			}
+			err := findArcs.Execute(vm, ctx)
+			require.NoError(t, err)
+
+			for i, expected := range tc.rangeChecks {
+				expectedFelt := f.Element{}
+				expectedFelt.SetBigInt(expected)
+				require.Equal(
+					t,
+					memory.MemoryValueFromFieldElement(&expectedFelt),
+					readFrom(vm, 2, uint64(i)),
+					"range check %d", i,
+				)
+			}
+
+			var skipA ApCellRef = 1
+			firstArc := AssertLeIsFirstArcExcluded{skipExcludeAFlag: skipA}
+			err = firstArc.Execute(vm, ctx)
+			require.NoError(t, err)
+			require.Equal(t, memory.MemoryValueFromUint(tc.skipA), readFrom(vm, VM.ExecutionSegment, 1))
+
+			var skipBMinusA ApCellRef = 2
+			secondArc := AssertLeIsSecondArcExcluded{skipExcludeBMinusA: skipBMinusA}
+			err = secondArc.Execute(vm, ctx)
+			require.NoError(t, err)
+			require.Equal(t, memory.MemoryValueFromUint(tc.skipBMinusA), readFrom(vm, VM.ExecutionSegment, 2))
+		})
+	}
+}
+
+func TestAssertLeFindSmallArcsAGreaterThanB(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	writeTo(vm, VM.ExecutionSegment, 0, writeSegment(vm))
+
+	var rangeCheckPtr ApCellRef = 0
+	hint := AssertLeFindSmallArcs{
+		rangeCheckPtr: Deref{rangeCheckPtr},
+		a:             Immediate(*big.NewInt(3)),
+		b:             Immediate(*big.NewInt(2)),
+	}
+	err := hint.Execute(vm, NewHintRunnerContext())
+	require.ErrorContains(t, err, "a = 3 is not less than or equal to b = 2")
+}
+
+func TestAssertLeIsFirstArcExcludedWithoutArcs(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	var skipA ApCellRef = 0
+	hint := AssertLeIsFirstArcExcluded{skipExcludeAFlag: skipA}
+	err := hint.Execute(vm, NewHintRunnerContext())
+	require.ErrorContains(t, err, "variable excluded not found in scope")
+}
+
+func TestAssertApDelta(t *testing.T) {
+	vm := defaultVirtualMachine()
+	vm.Context.Ap = 3
+	ctx := NewHintRunnerContext()
+
+	err := RecordAp{}.Execute(vm, ctx)
+	require.NoError(t, err)
+
+	vm.Context.Ap = 8
+	err = AssertApDelta{delta: 5}.Execute(vm, ctx)
+	require.NoError(t, err)
+
+	// the previous assertion recorded the new ap
+	vm.Context.Ap = 10
+	err = AssertApDelta{delta: 2}.Execute(vm, ctx)
+	require.NoError(t, err)
+}
+
+func TestAssertApDeltaMismatch(t *testing.T) {
+	testCases := []struct {
+		name  string
+		ap    uint64
+		delta uint64
+	}{
+		{"advanced too little", 6, 4},
+		{"advanced too much", 12, 4},
+		{"went back", 1, 4},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+			vm.Context.Ap = 3
+			ctx := NewHintRunnerContext()
+
+			err := RecordAp{}.Execute(vm, ctx)
+			require.NoError(t, err)
+
+			vm.Context.Ap = tc.ap
+			err = AssertApDelta{delta: tc.delta}.Execute(vm, ctx)
+			require.ErrorContains(
+				t,
+				err,
+				fmt.Sprintf("ap %d should have advanced by %d from the previous ap 3", tc.ap, tc.delta),
+			)
+		})
+	}
+}
+
+func TestAssertApDeltaWithoutRecord(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	err := AssertApDelta{delta: 0}.Execute(vm, NewHintRunnerContext())
+	require.ErrorContains(t, err, "no previous ap recorded")
+}
+
+func TestSerializeSquashedDict(t *testing.T) {
+	vm := defaultVirtualMachine()
+	ctx := NewHintRunnerContext()
+
+	dictAddr := ctx.DictionaryManager.NewDictionary(vm, memory.MemoryValueFromInt(0))
+	// accesses as (key, prev_value, new_value), key 7 is accessed twice
+	accesses := []int{
+		7, 0, 10,
+		3, 0, 5,
+		7, 10, 20,
+		-1, 1, 2,
+	}
+	for i, v := range accesses {
+		writeTo(vm, dictAddr.SegmentIndex, uint64(i), memory.MemoryValueFromInt(v))
+	}
+	dictEnd := memory.MemoryAddress{SegmentIndex: dictAddr.SegmentIndex, Offset: uint64(len(accesses))}
+	writeTo(vm, VM.ExecutionSegment, 0, memory.MemoryValueFromMemoryAddress(&dictEnd))
+	writeTo(vm, VM.ExecutionSegment, 1, writeSegment(vm))
+
+	var dictEndRef ApCellRef = 0
+	var dstRef ApCellRef = 1
+	hint := SerializeSquashedDict{
+		dictEnd: Deref{dictEndRef},
+		dst:     Deref{dstRef},
+	}
+
+	err := hint.Execute(vm, ctx)
+	require.ErrorContains(t, err, "dictionary at 2:12 is used before being squashed")
+
+	dict, err := ctx.DictionaryManager.GetDictionary(&dictAddr)
+	require.NoError(t, err)
+	require.NoError(t, dict.Squash())
+
+	err = hint.Execute(vm, ctx)
+	require.NoError(t, err)
+
+	expected := []int{
+		3, 0, 5,
+		7, 0, 20,
+		-1, 1, 2,
+	}
+	require.Equal(t, uint64(len(expected)), vm.Memory.Segments[3].Len())
+	for i, v := range expected {
+		require.Equal(t, memory.MemoryValueFromInt(v), readFrom(vm, 3, uint64(i)), "cell %d", i)
+	}
+}
+
+func TestSerializeSquashedDictPartialAccess(t *testing.T) {
+	vm := defaultVirtualMachine()
+	ctx := NewHintRunnerContext()
+
+	dictAddr := ctx.DictionaryManager.NewDictionary(vm, memory.MemoryValueFromInt(0))
+	dict, err := ctx.DictionaryManager.GetDictionary(&dictAddr)
+	require.NoError(t, err)
+	require.NoError(t, dict.Squash())
+
+	dictEnd := memory.MemoryAddress{SegmentIndex: dictAddr.SegmentIndex, Offset: 4}
+	writeTo(vm, VM.ExecutionSegment, 0, memory.MemoryValueFromMemoryAddress(&dictEnd))
+	writeTo(vm, VM.ExecutionSegment, 1, writeSegment(vm))
+
+	var dictEndRef ApCellRef = 0
+	var dstRef ApCellRef = 1
+	hint := SerializeSquashedDict{
+		dictEnd: Deref{dictEndRef},
+		dst:     Deref{dstRef},
+	}
+
+	err = hint.Execute(vm, ctx)
+	require.ErrorContains(t, err, "dictionary end offset 4 is not a multiple of the access size")
+}
+
+func TestFelt252DictNew(t *testing.T) {
+	vm := defaultVirtualMachine()
+	ctx := NewHintRunnerContext()
+
+	var firstDst ApCellRef = 0
+	var secondDst ApCellRef = 1
+	require.NoError(t, Felt252DictNew{dst: firstDst}.Execute(vm, ctx))
+	require.NoError(t, Felt252DictNew{dst: secondDst}.Execute(vm, ctx))
+
+	require.Equal(t, memory.MemoryValueFromSegmentAndOffset(2, 0), readFrom(vm, VM.ExecutionSegment, 0))
+	require.Equal(t, memory.MemoryValueFromSegmentAndOffset(3, 0), readFrom(vm, VM.ExecutionSegment, 1))
+	require.Equal(t, 2, len(ctx.DictionaryManager.dictionaries))
+
+	for _, segment := range []uint64{2, 3} {
+		dict, err := ctx.DictionaryManager.GetDictionary(&memory.MemoryAddress{SegmentIndex: segment, Offset: 0})
+		require.NoError(t, err)
+		require.Equal(t, segment, dict.SegmentIndex())
+		require.Equal(t, memory.MemoryValueFromInt(0), dict.defaultValue)
+		require.False(t, dict.Squashed())
+	}
+}
+
+func TestUint384MulMod(t *testing.T) {
+	fromHex := func(value string) *big.Int {
+		result, _ := new(big.Int).SetString(value, 16)
+		return result
+	}
+
+	// BLS12-381 base field modulus and the coordinates of its G1 generator
+	blsModulus := fromHex("1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab")
+	generatorX := fromHex("17f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb")
+	generatorY := fromHex("08b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1")
+
+	minusOne := new(big.Int).Sub(blsModulus, big.NewInt(1))
+	half := new(big.Int).Rsh(new(big.Int).Add(blsModulus, big.NewInt(1)), 1)
+
+	testCases := []struct {
+		name     string
+		a, b     *big.Int
+		expected *big.Int
+	}{
+		{"generator coordinates", generatorX, generatorY, fromHex("1144f72e5d8a469db166f58521e70676db2c6defa37e40da314436a0645f2511037bf2f1a83aa341bafe74514c615fae")},
+		{"minus one squared", minusOne, minusOne, big.NewInt(1)},
+		{"inverse of two", half, big.NewInt(2), big.NewInt(1)},
+		{"multiply by zero", generatorX, big.NewInt(0), big.NewInt(0)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			hint := Uint384MulMod{
+				a:       uint384Immediates(tc.a),
+				b:       uint384Immediates(tc.b),
+				modulus: uint384Immediates(blsModulus),
+				result:  [4]CellRefer{ApCellRef(0), ApCellRef(1), ApCellRef(2), ApCellRef(3)},
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+
+			for i, limb := range uint384Immediates(tc.expected) {
+				expected, err := limb.Resolve(vm)
+				require.NoError(t, err)
+				require.Equal(t, expected, readFrom(vm, VM.ExecutionSegment, uint64(i)), "limb %d", i)
+			}
+		})
+	}
+}
+
+func TestUint384MulModLimbTooLarge(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	one := Immediate(*big.NewInt(1))
+	zero := Immediate(*big.NewInt(0))
+	tooLarge := Immediate(*new(big.Int).Lsh(big.NewInt(1), 96))
+	hint := Uint384MulMod{
+		a:       [4]ResOperander{one, tooLarge, zero, zero},
+		b:       [4]ResOperander{one, zero, zero, zero},
+		modulus: [4]ResOperander{zero, zero, zero, one},
+		result:  [4]CellRefer{ApCellRef(0), ApCellRef(1), ApCellRef(2), ApCellRef(3)},
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "limb 1 79228162514264337593543950336 should be u96")
+}
+
+func TestFelt252DictReadWrite(t *testing.T) {
+	vm := defaultVirtualMachine()
+	ctx := NewHintRunnerContext()
+
+	var dictRef ApCellRef = 0
+	require.NoError(t, Felt252DictNew{dst: dictRef}.Execute(vm, ctx))
+
+	key := Immediate(*big.NewInt(42))
+	read := func(dst ApCellRef) {
+		hint := Felt252DictRead{dictPtr: Deref{dictRef}, key: key, valueDst: dst}
+		require.NoError(t, hint.Execute(vm, ctx))
+	}
+	write := func(value int64, prevDst ApCellRef) {
+		hint := Felt252DictWrite{
+			dictPtr:      Deref{dictRef},
+			key:          key,
+			value:        Immediate(*big.NewInt(value)),
+			prevValueDst: prevDst,
+		}
+		require.NoError(t, hint.Execute(vm, ctx))
+	}
+
+	// unset keys read as the default value
+	read(1)
+	require.Equal(t, memory.MemoryValueFromInt(0), readFrom(vm, VM.ExecutionSegment, 1))
+
+	write(7, 2)
+	require.Equal(t, memory.MemoryValueFromInt(0), readFrom(vm, VM.ExecutionSegment, 2))
+
+	// overwrites return the previous value
+	write(9, 3)
+	require.Equal(t, memory.MemoryValueFromInt(7), readFrom(vm, VM.ExecutionSegment, 3))
+
+	read(4)
+	require.Equal(t, memory.MemoryValueFromInt(9), readFrom(vm, VM.ExecutionSegment, 4))
+
+	dict, err := ctx.DictionaryManager.GetDictionary(&memory.MemoryAddress{SegmentIndex: 2, Offset: 0})
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), dict.AccessCount())
+}
+
+func TestFelt252DictReadWriteUnknownDict(t *testing.T) {
+	vm := defaultVirtualMachine()
+	ctx := NewHintRunnerContext()
+
+	writeTo(vm, VM.ExecutionSegment, 0, writeSegment(vm))
+
+	var dictRef ApCellRef = 0
+	read := Felt252DictRead{
+		dictPtr:  Deref{dictRef},
+		key:      Immediate(*big.NewInt(1)),
+		valueDst: ApCellRef(1),
+	}
+	err := read.Execute(vm, ctx)
+	require.ErrorContains(t, err, "no dictionary at segment 2")
+
+	write := Felt252DictWrite{
+		dictPtr:      Deref{dictRef},
+		key:          Immediate(*big.NewInt(1)),
+		value:        Immediate(*big.NewInt(2)),
+		prevValueDst: ApCellRef(1),
+	}
+	err = write.Execute(vm, ctx)
+	require.ErrorContains(t, err, "no dictionary at segment 2")
+}
+
+func TestIsInBls12381G1Subgroup(t *testing.T) {
+	fromDecimal := func(value string) *big.Int {
+		result, _ := new(big.Int).SetString(value, 10)
+		return result
+	}
+	fromHex := func(value string) *big.Int {
+		result, _ := new(big.Int).SetString(value, 16)
+		return result
+	}
+
+	testCases := []struct {
+		name     string
+		x, y     *big.Int
+		expected uint64
+	}{
+		{
+			name:     "generator",
+			x:        fromDecimal("3685416753713387016781088315183077757961620795782546409894578378688607592378376318836054947676345821548104185464507"),
+			y:        fromDecimal("1339506544944476473020471379941921221584933875938349620426543736416511423956333506472724655353366534992391756441569"),
+			expected: 1,
+		},
+		{
+			// on the curve, but its cofactor was not cleared
+			name:     "outside of the subgroup",
+			x:        big.NewInt(4),
+			y:        fromHex("0a989badd40d6212b33cffc3f3763e9bc760f988c9926b26da9dd85e928483446346b8ed00e1de5d5ea93e354abe706c"),
+			expected: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			var dst ApCellRef = 0
+			hint := IsInBls12381G1Subgroup{
+				x:   uint384Immediates(tc.x),
+				y:   uint384Immediates(tc.y),
+				dst: dst,
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(t, memory.MemoryValueFromUint(tc.expected), readFrom(vm, VM.ExecutionSegment, 0))
+		})
+	}
+}
+
+func TestIsInBls12381G1SubgroupNotOnCurve(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	var dst ApCellRef = 0
+	hint := IsInBls12381G1Subgroup{
+		x:   uint384Immediates(big.NewInt(4)),
+		y:   uint384Immediates(big.NewInt(5)),
+		dst: dst,
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "point (4, 5) is not on the curve")
+}
+
+func TestSquashDictInner(t *testing.T) {
+	vm := defaultVirtualMachine()
+	ctx := NewHintRunnerContext()
+
+	// accesses as (key, prev_value, new_value), key 2 is accessed at indices 1 and 3
+	// and key 5 at indices 0, 2 and 4
+	accesses := []memory.MemoryValue{}
+	for _, v := range []int{5, 0, 1, 2, 0, 3, 5, 1, 4, 2, 3, 6, 5, 4, 7} {
+		accesses = append(accesses, memory.MemoryValueFromInt(v))
+	}
+	writeTo(vm, VM.ExecutionSegment, 0, writeSegment(vm, accesses...))
+	writeTo(vm, VM.ExecutionSegment, 1, writeSegment(vm))
+
+	var dictAccesses ApCellRef = 0
+	var rangeCheckPtr ApCellRef = 1
+	rangeCheckAt := func(offset int16) ResOperander {
+		return BinaryOp{
+			operator: Add,
+			lhs:      rangeCheckPtr,
+			rhs:      Immediate(*big.NewInt(int64(offset))),
+		}
+	}
+	// every hint writes to a fresh cell
+	next := ApCellRef(2)
+	cell := func() ApCellRef {
+		next++
+		return next - 1
+	}
+	requireCell := func(ref ApCellRef, expected uint64) {
+		t.Helper()
+		require.Equal(t, memory.MemoryValueFromUint(expected), readFrom(vm, VM.ExecutionSegment, uint64(ref)))
+	}
+
+	bigKeys, firstKey := cell(), cell()
+	init := SquashDictInit{
+		dictAccesses: Deref{dictAccesses},
+		ptrDiff:      Immediate(*big.NewInt(int64(len(accesses)))),
+		nAccesses:    Immediate(*big.NewInt(int64(len(accesses) / 3))),
+		bigKeys:      bigKeys,
+		firstKey:     firstKey,
+	}
+	require.NoError(t, init.Execute(vm, ctx))
+	requireCell(bigKeys, 0)
+	requireCell(firstKey, 2)
+
+	// key 2
+	require.NoError(t, SquashDictInnerFirstIteration{rangeCheckPtr: rangeCheckAt(0)}.Execute(vm, ctx))
+	require.Equal(t, memory.MemoryValueFromUint(uint64(1)), readFrom(vm, 3, 0))
+
+	skip := cell()
+	require.NoError(t, SquashDictInnerSkipLoop{shouldSkipLoop: skip}.Execute(vm, ctx))
+	requireCell(skip, 0)
+
+	delta := cell()
+	require.NoError(t, SquashDictInnerCheckAccessIndex{indexDeltaMinus1: delta}.Execute(vm, ctx))
+	requireCell(delta, 1)
+
+	cont := cell()
+	require.NoError(t, SquashDictInnerContinueLoop{shouldContinue: cont}.Execute(vm, ctx))
+	requireCell(cont, 0)
+
+	nextKey := cell()
+	require.NoError(t, SquashDictInnerNextKey{nextKey: nextKey}.Execute(vm, ctx))
+	requireCell(nextKey, 5)
+
+	// key 5
+	require.NoError(t, SquashDictInnerFirstIteration{rangeCheckPtr: rangeCheckAt(1)}.Execute(vm, ctx))
+	require.Equal(t, memory.MemoryValueFromUint(uint64(0)), readFrom(vm, 3, 1))
+
+	skip = cell()
+	require.NoError(t, SquashDictInnerSkipLoop{shouldSkipLoop: skip}.Execute(vm, ctx))
+	requireCell(skip, 0)
+
+	for _, continues := range []uint64{1, 0} {
+		delta = cell()
+		require.NoError(t, SquashDictInnerCheckAccessIndex{indexDeltaMinus1: delta}.Execute(vm, ctx))
+		requireCell(delta, 1)
+
+		cont = cell()
+		require.NoError(t, SquashDictInnerContinueLoop{shouldContinue: cont}.Execute(vm, ctx))
+		requireCell(cont, continues)
+	}
+
+	require.NoError(t, SquashDictInnerAssertLenKeys{}.Execute(vm, ctx))
+
+	err := SquashDictInnerNextKey{nextKey: cell()}.Execute(vm, ctx)
+	require.ErrorContains(t, err, "no keys left but remaining accesses > 0")
+}
+
+func TestSquashDictInitBigKeys(t *testing.T) {
+	vm := defaultVirtualMachine()
+	ctx := NewHintRunnerContext()
+
+	bigKey := memory.MemoryValueFromFieldElement(new(f.Element).SetBigInt(new(big.Int).Lsh(big.NewInt(1), 128)))
+	writeTo(vm, VM.ExecutionSegment, 0, writeSegment(
+		vm,
+		bigKey, memory.MemoryValueFromInt(0), memory.MemoryValueFromInt(1),
+		memory.MemoryValueFromInt(3), memory.MemoryValueFromInt(0), memory.MemoryValueFromInt(1),
+	))
+
+	var dictAccesses ApCellRef = 0
+	var bigKeys ApCellRef = 1
+	var firstKey ApCellRef = 2
+	hint := SquashDictInit{
+		dictAccesses: Deref{dictAccesses},
+		ptrDiff:      Immediate(*big.NewInt(6)),
+		nAccesses:    Immediate(*big.NewInt(2)),
+		bigKeys:      bigKeys,
+		firstKey:     firstKey,
+	}
+	require.NoError(t, hint.Execute(vm, ctx))
+	require.Equal(t, memory.MemoryValueFromUint(uint64(1)), readFrom(vm, VM.ExecutionSegment, 1))
+	require.Equal(t, memory.MemoryValueFromUint(uint64(3)), readFrom(vm, VM.ExecutionSegment, 2))
+
+	// one key is left to squash
+	err := SquashDictInnerAssertLenKeys{}.Execute(vm, ctx)
+	require.ErrorContains(t, err, "1 keys have not been squashed")
+}
+
+func TestSquashDictKeyOrder(t *testing.T) {
+	testCases := []struct {
+		name       string
+		descending bool
+		firstKey   uint64
+		nextKey    uint64
+	}{
+		{name: "ascending", descending: false, firstKey: 2, nextKey: 5},
+		{name: "descending", descending: true, firstKey: 5, nextKey: 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+			ctx := NewHintRunnerContext()
+
+			writeTo(vm, VM.ExecutionSegment, 0, writeSegment(
+				vm,
+				memory.MemoryValueFromInt(5), memory.MemoryValueFromInt(0), memory.MemoryValueFromInt(1),
+				memory.MemoryValueFromInt(2), memory.MemoryValueFromInt(0), memory.MemoryValueFromInt(3),
+			))
+
+			var dictAccesses ApCellRef = 0
+			var bigKeys ApCellRef = 1
+			var firstKey ApCellRef = 2
+			var nextKey ApCellRef = 3
+			init := SquashDictInit{
+				dictAccesses: Deref{dictAccesses},
+				ptrDiff:      Immediate(*big.NewInt(6)),
+				nAccesses:    Immediate(*big.NewInt(2)),
+				bigKeys:      bigKeys,
+				firstKey:     firstKey,
+				descending:   tc.descending,
+			}
+			require.NoError(t, init.Execute(vm, ctx))
+			require.Equal(t, memory.MemoryValueFromUint(tc.firstKey), readFrom(vm, VM.ExecutionSegment, 2))
+
+			require.NoError(t, SquashDictInnerNextKey{nextKey: nextKey}.Execute(vm, ctx))
+			require.Equal(t, memory.MemoryValueFromUint(tc.nextKey), readFrom(vm, VM.ExecutionSegment, 3))
+
+			inOrder := SquashDictAssertKeyOrder{prevKey: Deref{firstKey}, nextKey: Deref{nextKey}}
+			require.NoError(t, inOrder.Execute(vm, ctx))
+
+			outOfOrder := SquashDictAssertKeyOrder{prevKey: Deref{nextKey}, nextKey: Deref{firstKey}}
+			require.Error(t, outOfOrder.Execute(vm, ctx))
+
+			repeated := SquashDictAssertKeyOrder{prevKey: Deref{firstKey}, nextKey: Deref{firstKey}}
+			require.Error(t, repeated.Execute(vm, ctx))
+		})
+	}
+}
+
+func TestSquashDictAssertKeyOrderErrors(t *testing.T) {
+	vm := defaultVirtualMachine()
+	ctx := NewHintRunnerContext()
+
+	hint := SquashDictAssertKeyOrder{
+		prevKey: Immediate(*big.NewInt(3)),
+		nextKey: Immediate(*big.NewInt(1)),
+	}
+	err := hint.Execute(vm, ctx)
+	require.ErrorContains(t, err, "variable squash_descending not found in scope")
+
+	ctx.SetVariable("squash_descending", false)
+	err = hint.Execute(vm, ctx)
+	require.ErrorContains(t, err, "keys should be ascending: 1 is not greater than 3")
+
+	ctx.SetVariable("squash_descending", true)
+	require.NoError(t, hint.Execute(vm, ctx))
+
+	hint.nextKey = Immediate(*big.NewInt(4))
+	err = hint.Execute(vm, ctx)
+	require.ErrorContains(t, err, "keys should be descending: 4 is not smaller than 3")
+}
+
+func TestBatchInverse(t *testing.T) {
+	testCases := []struct {
+		name   string
+		values []int
+	}{
+		{"several values", []int{1, 2, 3, -1, 1 << 40, 7}},
+		{"single value", []int{5}},
+		{"empty batch", []int{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			values := intValues(tc.values...)
+			writeTo(vm, VM.ExecutionSegment, 0, writeSegment(vm, values...))
+			writeTo(vm, VM.ExecutionSegment, 1, writeSegment(vm))
+
+			var srcRef ApCellRef = 0
+			var dstRef ApCellRef = 1
+			hint := BatchInverse{
+				src:    Deref{srcRef},
+				dst:    Deref{dstRef},
+				length: Immediate(*big.NewInt(int64(len(tc.values)))),
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+
+			require.Equal(t, uint64(len(tc.values)), vm.Memory.Segments[3].Len())
+			for i := range tc.values {
+				input, err := values[i].FieldElement()
+				require.NoError(t, err)
+				mv := readFrom(vm, 3, uint64(i))
+				output, err := mv.FieldElement()
+				require.NoError(t, err)
+
+				product := new(f.Element).Mul(input, output)
+				require.True(t, product.IsOne(), "inverse %d", i)
+			}
+		})
+	}
+}
+
+func TestBatchInverseZero(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	writeTo(vm, VM.ExecutionSegment, 0, writeSegment(
+		vm,
+		memory.MemoryValueFromInt(3),
+		memory.MemoryValueFromInt(4),
+		memory.MemoryValueFromInt(0),
+	))
+	writeTo(vm, VM.ExecutionSegment, 1, writeSegment(vm))
+
+	var srcRef ApCellRef = 0
+	var dstRef ApCellRef = 1
+	hint := BatchInverse{
+		src:    Deref{srcRef},
+		dst:    Deref{dstRef},
+		length: Immediate(*big.NewInt(3)),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "cannot invert zero at index 2")
+}
+
+func TestAssertNotZero(t *testing.T) {
+	testCases := []struct {
+		name        string
+		value       *big.Int
+		expectedErr string
+	}{
+		{"non zero value", big.NewInt(3), ""},
+		{"minus one", new(big.Int).Sub(f.Modulus(), big.NewInt(1)), ""},
+		{"zero", big.NewInt(0), "value = 0 should not be zero"},
+		{"prime", f.Modulus(), "value = 0 should not be zero"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			err := AssertNotZero{value: Immediate(*tc.value)}.Execute(vm, nil)
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr)
+			}
+			require.Equal(t, uint64(0), vm.Memory.Segments[VM.ExecutionSegment].Len())
+		})
+	}
+}
+
+func TestAssertLtFelt(t *testing.T) {
+	testCases := []struct {
+		name        string
+		a, b        *big.Int
+		expectedErr string
+	}{
+		{"smaller", big.NewInt(3), big.NewInt(4), ""},
+		{"largest felt", big.NewInt(0), new(big.Int).Sub(f.Modulus(), big.NewInt(1)), ""},
+		{"equal", big.NewInt(4), big.NewInt(4), "a = 4 is not less than b = 4"},
+		{"greater", big.NewInt(5), big.NewInt(4), "a = 5 is not less than b = 4"},
+		{
+			// -1 is p - 1 in the integer interpretation
+			"negative", new(big.Int).Sub(f.Modulus(), big.NewInt(1)), big.NewInt(4),
+			"is not less than b = 4",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			err := AssertLtFelt{a: Immediate(*tc.a), b: Immediate(*tc.b)}.Execute(vm, nil)
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr)
+			}
+			require.Equal(t, uint64(0), vm.Memory.Segments[VM.ExecutionSegment].Len())
+		})
+	}
+}
+
+func TestLinearSplit(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		value, scalar, maxX  int64
+		expectedX, expectedY int64
+		expectedErr          string
+	}{
+		{name: "unclamped", value: 47, scalar: 10, maxX: 100, expectedX: 4, expectedY: 7},
+		{name: "exactly the max", value: 47, scalar: 10, maxX: 4, expectedX: 4, expectedY: 7},
+		{name: "clamped", value: 47, scalar: 10, maxX: 2, expectedX: 2, expectedY: 27},
+		{name: "zero value", value: 0, scalar: 10, maxX: 2, expectedX: 0, expectedY: 0},
+		{name: "zero scalar", value: 47, scalar: 0, maxX: 2, expectedErr: "cannot divide by zero"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			var x ApCellRef = 0
+			var y ApCellRef = 1
+			hint := LinearSplit{
+				value:  Immediate(*big.NewInt(tc.value)),
+				scalar: Immediate(*big.NewInt(tc.scalar)),
+				maxX:   Immediate(*big.NewInt(tc.maxX)),
+				x:      x,
+				y:      y,
+			}
+
+			err := hint.Execute(vm, nil)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, memory.MemoryValueFromInt(tc.expectedX), readFrom(vm, VM.ExecutionSegment, 0))
+			require.Equal(t, memory.MemoryValueFromInt(tc.expectedY), readFrom(vm, VM.ExecutionSegment, 1))
+		})
+	}
+}
+
+func TestVectorCommit(t *testing.T) {
+	testCases := []struct {
+		name     string
+		values   []int
+		expected string
+	}{
+		{"empty vector", []int{}, "0x1"},
+		{"single value", []int{2}, "0x5bb9440e27889a364bcb678b1f679ecd1347acdedcbf36e83494f857cc58026"},
+		{"several values", []int{2, 3, 4}, "0x4c1cec8ca0d266e102559432703b9807b75dae05048908f6dedcb29f125e2da"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			values := intValues(tc.values...)
+			writeTo(vm, VM.ExecutionSegment, 0, writeSegment(vm, values...))
+
+			var start ApCellRef = 0
+			var dst ApCellRef = 1
+			hint := VectorCommit{
+				start:    Deref{start},
+				length:   Immediate(*big.NewInt(int64(len(tc.values)))),
+				blinding: Immediate(*big.NewInt(1)),
+				dst:      dst,
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+
+			expected, err := new(f.Element).SetString(tc.expected)
+			require.NoError(t, err)
+			require.Equal(t, memory.MemoryValueFromFieldElement(expected), readFrom(vm, VM.ExecutionSegment, 1))
+		})
+	}
+}
+
+func TestRandomEcPoint(t *testing.T) {
+	vm := defaultVirtualMachine()
+	ctx := NewHintRunnerContext()
+
+	a, b := starkcurve.CurveCoefficients()
+	points := []starkcurve.G1Affine{}
+	for i := 0; i < 3; i++ {
+		var x ApCellRef = ApCellRef(2 * i)
+		var y ApCellRef = ApCellRef(2*i + 1)
+		err := RandomEcPoint{x: x, y: y}.Execute(vm, ctx)
+		require.NoError(t, err)
+
+		xValue := readFrom(vm, VM.ExecutionSegment, uint64(2*i))
+		xFelt, err := xValue.FieldElement()
+		require.NoError(t, err)
+		yValue := readFrom(vm, VM.ExecutionSegment, uint64(2*i+1))
+		yFelt, err := yValue.FieldElement()
+		require.NoError(t, err)
+
+		// y^2 = x^3 + a * x + b
+		lhs := new(f.Element).Square(yFelt)
+		rhs := new(f.Element).Square(xFelt)
+		rhs.Mul(rhs, xFelt)
+		rhs.Add(rhs, new(f.Element).Mul(&a, xFelt))
+		rhs.Add(rhs, &b)
+		require.Equal(t, rhs, lhs, "point %d", i)
+
+		point := starkcurve.G1Affine{X: *xFelt, Y: *yFelt}
+		require.True(t, point.IsOnCurve())
+		points = append(points, point)
+	}
+
+	require.NotEqual(t, points[0], points[1])
+	require.NotEqual(t, points[1], points[2])
+
+	// a new execution derives the same points
+	var x ApCellRef = 6
+	var y ApCellRef = 7
+	err := RandomEcPoint{x: x, y: y}.Execute(vm, NewHintRunnerContext())
+	require.NoError(t, err)
+	require.Equal(t, memory.MemoryValueFromFieldElement(&points[0].X), readFrom(vm, VM.ExecutionSegment, 6))
+	require.Equal(t, memory.MemoryValueFromFieldElement(&points[0].Y), readFrom(vm, VM.ExecutionSegment, 7))
+}
+
+func TestRlpPrefix(t *testing.T) {
+	testCases := []struct {
+		name           string
+		data           []uint64
+		expectedLength uint64
+		expectedOffset uint64
+		expectedIsList uint64
+		expectedErr    string
+	}{
+		{name: "single byte", data: []uint64{0x7f}, expectedLength: 1, expectedOffset: 0},
+		{name: "empty string", data: []uint64{0x80}, expectedLength: 0, expectedOffset: 1},
+		// "dog"
+		{name: "short string", data: []uint64{0x83, 'd', 'o', 'g'}, expectedLength: 3, expectedOffset: 1},
+		// a 1024 bytes string, only the prefix is read
+		{name: "long string", data: []uint64{0xb9, 0x04, 0x00}, expectedLength: 1024, expectedOffset: 3},
+		// ["cat", "dog"]
+		{name: "short list", data: []uint64{0xc8, 0x83, 'c', 'a', 't'}, expectedLength: 8, expectedOffset: 1, expectedIsList: 1},
+		{name: "long list", data: []uint64{0xf8, 0x40}, expectedLength: 64, expectedOffset: 2, expectedIsList: 1},
+		{name: "leading zero", data: []uint64{0xb9, 0x00, 0x40}, expectedErr: "long length should not have leading zeros"},
+		{name: "non canonical long length", data: []uint64{0xb8, 0x20}, expectedErr: "long length 32 should be encoded as a short one"},
+		{name: "not a byte", data: []uint64{0x100}, expectedErr: "byte 0 256 should fit in 8 bits"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			values := intValues(tc.data...)
+			writeTo(vm, VM.ExecutionSegment, 0, writeSegment(vm, values...))
+
+			var data ApCellRef = 0
+			var length ApCellRef = 1
+			var offset ApCellRef = 2
+			var isList ApCellRef = 3
+			hint := RlpPrefix{
+				data:   Deref{data},
+				length: length,
+				offset: offset,
+				isList: isList,
+			}
+
+			err := hint.Execute(vm, nil)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, memory.MemoryValueFromUint(tc.expectedLength), readFrom(vm, VM.ExecutionSegment, 1))
+			require.Equal(t, memory.MemoryValueFromUint(tc.expectedOffset), readFrom(vm, VM.ExecutionSegment, 2))
+			require.Equal(t, memory.MemoryValueFromUint(tc.expectedIsList), readFrom(vm, VM.ExecutionSegment, 3))
+		})
+	}
+}
+
+func TestAssertClassHash(t *testing.T) {
+	testCases := []struct {
+		name        string
+		value       *big.Int
+		expectedErr string
+	}{
+		{"zero", big.NewInt(0), ""},
+		{"last valid class hash", new(big.Int).Sub(classHashBound, big.NewInt(1)), ""},
+		{"boundary", new(big.Int).Set(classHashBound), "is not a valid class hash"},
+		{"above boundary", new(big.Int).Sub(f.Modulus(), big.NewInt(1)), "is not a valid class hash"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+			hint := AssertClassHash{
+				value: Immediate(*tc.value),
+			}
+
+			err := hint.Execute(vm, nil)
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestKeccakByteArray(t *testing.T) {
+	// packs a string as a ByteArray, in full 31 bytes words and a pending word
+	pack := func(s string) ([]memory.MemoryValue, *big.Int, int64) {
+		words := []memory.MemoryValue{}
+		for len(s) >= byteArrayWordBytes {
+			word := new(big.Int).SetBytes([]byte(s[:byteArrayWordBytes]))
+			words = append(words, memory.MemoryValueFromFieldElement(new(f.Element).SetBigInt(word)))
+			s = s[byteArrayWordBytes:]
+		}
+		return words, new(big.Int).SetBytes([]byte(s)), int64(len(s))
+	}
+
+	testCases := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{"empty byte array", "", "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"},
+		{"short string", "hello", "1c8aff950685c2ed4bc3174f3472287b56d9517b9c948127319a09a7a36deac8"},
+		{
+			"full word and pending word",
+			"The quick brown fox jumps over the lazy dog",
+			"4d741b6f1eb29cb2a9b9911c82f56fa8d73b04959d3d9d222895df6c0b28aa15",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			words, pendingWord, pendingWordLen := pack(tc.value)
+			writeTo(vm, VM.ExecutionSegment, 0, writeSegment(vm, words...))
+
+			var data ApCellRef = 0
+			var low ApCellRef = 1
+			var high ApCellRef = 2
+			hint := KeccakByteArray{
+				data:           Deref{data},
+				dataLen:        Immediate(*big.NewInt(int64(len(words)))),
+				pendingWord:    Immediate(*pendingWord),
+				pendingWordLen: Immediate(*big.NewInt(pendingWordLen)),
+				low:            low,
+				high:           high,
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+
+			expected, _ := new(big.Int).SetString(tc.expected, 16)
+			mask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+			expectedLow := new(f.Element).SetBigInt(new(big.Int).And(expected, mask))
+			expectedHigh := new(f.Element).SetBigInt(new(big.Int).Rsh(expected, 128))
+			require.Equal(t, memory.MemoryValueFromFieldElement(expectedLow), readFrom(vm, VM.ExecutionSegment, 1))
+			require.Equal(t, memory.MemoryValueFromFieldElement(expectedHigh), readFrom(vm, VM.ExecutionSegment, 2))
+		})
+	}
+}
+
+func TestKeccakByteArrayPendingWordTooLarge(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	writeTo(vm, VM.ExecutionSegment, 0, writeSegment(vm))
+
+	var data ApCellRef = 0
+	var low ApCellRef = 1
+	var high ApCellRef = 2
+	hint := KeccakByteArray{
+		data:           Deref{data},
+		dataLen:        Immediate(*big.NewInt(0)),
+		pendingWord:    Immediate(*big.NewInt(0x1234)),
+		pendingWordLen: Immediate(*big.NewInt(1)),
+		low:            low,
+		high:           high,
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "pending word 4660 should fit in 1 bytes")
+}
+
+func TestSha256Compress(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	words := func(values ...uint64) []memory.MemoryValue {
+		mvs := make([]memory.MemoryValue, len(values))
+		for i, v := range values {
+			mvs[i] = memory.MemoryValueFromUint(v)
+		}
+		return mvs
+	}
+
+	// sha256 initial state, compressed with the padded single block message "abc"
+	writeTo(vm, VM.ExecutionSegment, 0, writeSegment(vm, words(
+		0x6a09e667, 0xbb67ae85, 0x3c6ef372, 0xa54ff53a, 0x510e527f, 0x9b05688c, 0x1f83d9ab, 0x5be0cd19,
+	)...))
+	writeTo(vm, VM.ExecutionSegment, 1, writeSegment(vm, words(
+		0x61626380, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x18,
+	)...))
+	writeTo(vm, VM.ExecutionSegment, 2, writeSegment(vm))
+
+	var state ApCellRef = 0
+	var block ApCellRef = 1
+	var dst ApCellRef = 2
+	hint := Sha256Compress{
+		state: Deref{state},
+		block: Deref{block},
+		dst:   Deref{dst},
+	}
+
+	err := hint.Execute(vm, nil)
+	require.NoError(t, err)
+
+	// sha256("abc")
+	expected := words(0xba7816bf, 0x8f01cfea, 0x414140de, 0x5dae2223, 0xb00361a3, 0x96177a9c, 0xb410ff61, 0xf20015ad)
+	require.Equal(t, uint64(len(expected)), vm.Memory.Segments[4].Len())
+	for i := range expected {
+		require.Equal(t, expected[i], readFrom(vm, 4, uint64(i)), "word %d", i)
+	}
+}
+
+func TestSha256CompressWordTooLarge(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	state := make([]memory.MemoryValue, 8)
+	for i := range state {
+		state[i] = memory.MemoryValueFromUint(uint64(0))
+	}
+	state[3] = memory.MemoryValueFromUint(uint64(1 << 32))
+	writeTo(vm, VM.ExecutionSegment, 0, writeSegment(vm, state...))
+
+	var stateRef ApCellRef = 0
+	hint := Sha256Compress{
+		state: Deref{stateRef},
+		block: Deref{stateRef},
+		dst:   Deref{stateRef},
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "read state: word 3 4294967296 should be u32")
+}
+
+func TestLogBase(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    *big.Int
+		base     int64
+		expected uint64
+	}{
+		{name: "base 10", value: big.NewInt(12345), base: 10, expected: 4},
+		{name: "base 10 power", value: big.NewInt(1000), base: 10, expected: 3},
+		{name: "base 2", value: new(big.Int).Lsh(big.NewInt(1), 200), base: 2, expected: 200},
+		{name: "base 2 below power", value: big.NewInt(1023), base: 2, expected: 9},
+		{name: "value 1", value: big.NewInt(1), base: 7, expected: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			var dst ApCellRef = 0
+			hint := LogBase{
+				value: Immediate(*tc.value),
+				base:  Immediate(*big.NewInt(tc.base)),
+				dst:   dst,
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(t, memory.MemoryValueFromUint(tc.expected), readFrom(vm, VM.ExecutionSegment, 0))
+		})
+	}
+}
+
+func TestLogBaseErrors(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	var dst ApCellRef = 0
+	hint := LogBase{
+		value: Immediate(*big.NewInt(0)),
+		base:  Immediate(*big.NewInt(10)),
+		dst:   dst,
+	}
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "log of 0 is undefined")
+
+	hint.value = Immediate(*big.NewInt(8))
+	hint.base = Immediate(*big.NewInt(1))
+	err = hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "base 1 should be at least 2")
+}
+
+func TestAssertShortString(t *testing.T) {
+	testCases := []struct {
+		name        string
+		value       *big.Int
+		expectedErr string
+	}{
+		{name: "valid", value: new(big.Int).SetBytes([]byte("hello world"))},
+		{name: "empty", value: big.NewInt(0)},
+		{name: "31 bytes", value: new(big.Int).SetBytes([]byte("abcdefghijklmnopqrstuvwxyz01234"))},
+		{
+			name:        "high byte",
+			value:       new(big.Int).SetBytes([]byte("caf\xe9")),
+			expectedErr: "has a non printable byte 0xe9 at position 3",
+		},
+		{
+			name:        "embedded null",
+			value:       new(big.Int).SetBytes([]byte("ab\x00cd")),
+			expectedErr: "has a null byte at position 2",
+		},
+		{
+			name:        "too long",
+			value:       new(big.Int).Lsh(big.NewInt(1), 8*31),
+			expectedErr: "is 32 bytes long, more than 31",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			hint := AssertShortString{value: Immediate(*tc.value)}
+			err := hint.Execute(vm, nil)
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestRangeCheckDecompose(t *testing.T) {
+	testCases := []struct {
+		name        string
+		value       int64
+		nParts      int64
+		bitWidth    int64
+		expected    []uint64
+		expectedErr string
+	}{
+		{
+			name:     "in range",
+			value:    0x123456789abc,
+			nParts:   3,
+			bitWidth: 16,
+			expected: []uint64{0x9abc, 0x5678, 0x1234},
+		},
+		{
+			name:     "leading zero parts",
+			value:    0x1ff,
+			nParts:   3,
+			bitWidth: 8,
+			expected: []uint64{0xff, 0x1, 0},
+		},
+		{
+			name:        "out of range",
+			value:       0x10000,
+			nParts:      2,
+			bitWidth:    8,
+			expectedErr: "value 65536 doesn't fit in 2 parts of 8 bits",
+		},
+		{
+			name:        "too wide",
+			value:       1,
+			nParts:      1,
+			bitWidth:    129,
+			expectedErr: "bit width 129 should be between 1 and 128",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			rangeCheckSegment := vm.Memory.AllocateBuiltinSegment(&builtins.RangeCheck{})
+			writeTo(vm, VM.ExecutionSegment, 0, memory.MemoryValueFromSegmentAndOffset(rangeCheckSegment, 0))
+
+			var rangeCheckPtr ApCellRef = 0
+			hint := RangeCheckDecompose{
+				rangeCheckPtr: Deref{rangeCheckPtr},
+				value:         Immediate(*big.NewInt(tc.value)),
+				nParts:        Immediate(*big.NewInt(tc.nParts)),
+				bitWidth:      Immediate(*big.NewInt(tc.bitWidth)),
+			}
+
+			err := hint.Execute(vm, nil)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				// nothing is written if the value doesn't decompose
+				require.Equal(t, uint64(0), vm.Memory.Segments[rangeCheckSegment].Len())
+				return
+			}
+			require.NoError(t, err)
+			for i, part := range tc.expected {
+				require.Equal(t, memory.MemoryValueFromUint(part), readFrom(vm, uint64(rangeCheckSegment), uint64(i)))
+			}
+		})
+	}
+}
+
+func TestIntervalOverlap(t *testing.T) {
+	testCases := []struct {
+		name           string
+		a1, a2, b1, b2 int64
+		expected       uint64
+	}{
+		{name: "overlapping", a1: 2, a2: 10, b1: 5, b2: 20, expected: 5},
+		{name: "overlapping reversed", a1: 5, a2: 20, b1: 2, b2: 10, expected: 5},
+		{name: "contained", a1: 0, a2: 100, b1: 30, b2: 40, expected: 10},
+		{name: "touching", a1: 1, a2: 3, b1: 3, b2: 7, expected: 0},
+		{name: "disjoint", a1: 1, a2: 3, b1: 8, b2: 9, expected: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			var dst ApCellRef = 0
+			hint := IntervalOverlap{
+				a1:  Immediate(*big.NewInt(tc.a1)),
+				a2:  Immediate(*big.NewInt(tc.a2)),
+				b1:  Immediate(*big.NewInt(tc.b1)),
+				b2:  Immediate(*big.NewInt(tc.b2)),
+				dst: dst,
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(t, memory.MemoryValueFromUint(tc.expected), readFrom(vm, VM.ExecutionSegment, 0))
+		})
+	}
+}
+
+func TestIntervalOverlapInvalidInterval(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	var dst ApCellRef = 0
+	hint := IntervalOverlap{
+		a1:  Immediate(*big.NewInt(1)),
+		a2:  Immediate(*big.NewInt(4)),
+		b1:  Immediate(*big.NewInt(6)),
+		b2:  Immediate(*big.NewInt(5)),
+		dst: dst,
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "interval [6, 5] has its start after its end")
+}
+
+func TestAssertSquashedDictLastWrite(t *testing.T) {
+	testCases := []struct {
+		name        string
+		squashed    []int
+		expectedErr string
+	}{
+		{
+			name:     "correct squash",
+			squashed: []int{3, 0, 5, 7, 0, 20, 9, 0, 0},
+		},
+		{
+			name:        "corrupted new value",
+			squashed:    []int{3, 0, 5, 7, 0, 10},
+			expectedErr: "key 7: squashed new value 10 differs from the last write 20",
+		},
+		{
+			name:        "partial access",
+			squashed:    []int{3, 0, 5, 7},
+			expectedErr: "squashed dict size 4 is not a multiple of the access size",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+			ctx := NewHintRunnerContext()
+
+			dictAddr := ctx.DictionaryManager.NewDictionary(vm, memory.MemoryValueFromInt(0))
+			dict, err := ctx.DictionaryManager.GetDictionary(&dictAddr)
+			require.NoError(t, err)
+			// key 7 is written twice and key 9 is only read
+			for _, write := range [][2]uint64{{3, 5}, {7, 10}, {7, 20}} {
+				key := f.NewElement(write[0])
+				value := memory.MemoryValueFromUint(write[1])
+				dict.Write(&key, &value)
+			}
+			key := f.NewElement(9)
+			dict.Read(&key)
+
+			squashed := []memory.MemoryValue{}
+			for _, v := range tc.squashed {
+				squashed = append(squashed, memory.MemoryValueFromInt(v))
+			}
+			squashedStart := writeSegment(vm, squashed...)
+			startAddr, err := squashedStart.MemoryAddress()
+			require.NoError(t, err)
+			squashedEnd := memory.MemoryValueFromSegmentAndOffset(startAddr.SegmentIndex, uint64(len(squashed)))
+
+			writeTo(vm, VM.ExecutionSegment, 0, memory.MemoryValueFromMemoryAddress(&dictAddr))
+			writeTo(vm, VM.ExecutionSegment, 1, squashedStart)
+			writeTo(vm, VM.ExecutionSegment, 2, squashedEnd)
+
+			var dictPtr ApCellRef = 0
+			var start ApCellRef = 1
+			var end ApCellRef = 2
+			hint := AssertSquashedDictLastWrite{
+				dictPtr:       Deref{dictPtr},
+				squashedStart: Deref{start},
+				squashedEnd:   Deref{end},
+			}
+
+			err = hint.Execute(vm, ctx)
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr)
+			}
+			// the check doesn't count as accesses
+			require.Equal(t, uint64(4), dict.AccessCount())
+		})
+	}
+}
+
+func TestPoseidonMerkleRoot(t *testing.T) {
+	felts := func(values ...uint64) []*f.Element {
+		elements := make([]*f.Element, len(values))
+		for i, v := range values {
+			element := f.NewElement(v)
+			elements[i] = &element
+		}
+		return elements
+	}
+	leaves := felts(1, 2, 3, 4)
+	// poseidon(1, 2) and poseidon(poseidon(1, 2), poseidon(3, 4)) reference values
+	left, err := new(f.Element).SetString("0x5d44a3decb2b2e0cc71071f7b802f45dd792d064f0fc7316c46514f70f9891a")
+	require.NoError(t, err)
+	fourLeavesRoot, err := new(f.Element).SetString("0x37c93a8507ea3cf33567ae2c6c33a0d86b997edcfc3b87280d9a572b2cde39b")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name        string
+		leaves      []*f.Element
+		expected    *f.Element
+		expectedErr string
+	}{
+		{name: "single leaf", leaves: leaves[:1], expected: leaves[0]},
+		{name: "two leaves", leaves: leaves[:2], expected: left},
+		{name: "four leaves", leaves: leaves, expected: fourLeavesRoot},
+		{name: "not a power of two", leaves: leaves[:3], expectedErr: "length 3 is not a power of two"},
+		{name: "empty", leaves: leaves[:0], expectedErr: "length 0 is not a power of two"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			values := make([]memory.MemoryValue, len(tc.leaves))
+			for i, leaf := range tc.leaves {
+				values[i] = memory.MemoryValueFromFieldElement(leaf)
+			}
+			writeTo(vm, VM.ExecutionSegment, 0, writeSegment(vm, values...))
+
+			var start ApCellRef = 0
+			var dst ApCellRef = 1
+			hint := PoseidonMerkleRoot{
+				start:  Deref{start},
+				length: Immediate(*big.NewInt(int64(len(tc.leaves)))),
+				dst:    dst,
+			}
+
+			err := hint.Execute(vm, nil)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, memory.MemoryValueFromFieldElement(tc.expected), readFrom(vm, VM.ExecutionSegment, 1))
+		})
+	}
+}
+
+func TestDelta(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	src := []uint64{3, 5, 5, 9, 20}
+	ranges := writeRanges(vm, intValues(src...), nil)
+	hint := Delta{
+		src:    ranges[0],
+		dst:    ranges[1],
+		length: Immediate(*big.NewInt(int64(len(src)))),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.NoError(t, err)
+
+	expected := []uint64{3, 2, 0, 4, 11}
+	require.Equal(t, uint64(len(expected)), vm.Memory.Segments[3].Len())
+	for i, v := range expected {
+		require.Equal(t, memory.MemoryValueFromUint(v), readFrom(vm, 3, uint64(i)))
+	}
+}
+
+func TestDeltaDecreasing(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	ranges := writeRanges(vm, intValues(5, 2), nil)
+	hint := Delta{
+		src:    ranges[0],
+		dst:    ranges[1],
+		length: Immediate(*big.NewInt(2)),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.NoError(t, err)
+	// differences wrap around the field
+	require.Equal(t, memory.MemoryValueFromInt(-3), readFrom(vm, 3, 1))
+}
+
+func TestUnDeltaRoundTrip(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	// a decreasing step makes a delta wrap around the field
+	src := []int{4, 10, 10, 7, 100}
+	ranges := writeRanges(vm, intValues(src...), nil, nil)
+	length := Immediate(*big.NewInt(int64(len(src))))
+
+	err := Delta{src: ranges[0], dst: ranges[1], length: length}.Execute(vm, nil)
+	require.NoError(t, err)
+	require.Equal(t, memory.MemoryValueFromInt(-3), readFrom(vm, 3, 3))
+
+	err = UnDelta{src: ranges[1], dst: ranges[2], length: length}.Execute(vm, nil)
+	require.NoError(t, err)
+
+	require.Equal(t, uint64(len(src)), vm.Memory.Segments[4].Len())
+	for i, v := range src {
+		require.Equal(t, memory.MemoryValueFromInt(v), readFrom(vm, 4, uint64(i)))
+	}
+}
+
+func TestAssertNondecreasingPointers(t *testing.T) {
+	testCases := []struct {
+		name        string
+		pointers    []memory.MemoryValue
+		expectedErr string
+	}{
+		{
+			name: "sorted",
+			pointers: []memory.MemoryValue{
+				memory.MemoryValueFromSegmentAndOffset(5, 0),
+				memory.MemoryValueFromSegmentAndOffset(5, 2),
+				memory.MemoryValueFromSegmentAndOffset(5, 2),
+				memory.MemoryValueFromSegmentAndOffset(5, 9),
+			},
+		},
+		{
+			name:     "empty",
+			pointers: []memory.MemoryValue{},
+		},
+		{
+			name: "unsorted",
+			pointers: []memory.MemoryValue{
+				memory.MemoryValueFromSegmentAndOffset(5, 1),
+				memory.MemoryValueFromSegmentAndOffset(5, 4),
+				memory.MemoryValueFromSegmentAndOffset(5, 3),
+				memory.MemoryValueFromSegmentAndOffset(5, 0),
+			},
+			expectedErr: "pointer 2 5:3 is smaller than pointer 1 5:4",
+		},
+		{
+			name: "different segments",
+			pointers: []memory.MemoryValue{
+				memory.MemoryValueFromSegmentAndOffset(5, 1),
+				memory.MemoryValueFromSegmentAndOffset(6, 4),
+			},
+			expectedErr: "pointer 1 6:4 is not in the same segment as pointer 0 5:1",
+		},
+		{
+			name: "not a pointer",
+			pointers: []memory.MemoryValue{
+				memory.MemoryValueFromSegmentAndOffset(5, 1),
+				memory.MemoryValueFromInt(7),
+			},
+			expectedErr: "read pointer 1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := defaultVirtualMachine()
+
+			hint := AssertNondecreasingPointers{
+				start:  writeRanges(vm, tc.pointers)[0],
+				length: Immediate(*big.NewInt(int64(len(tc.pointers)))),
+			}
+
+			err := hint.Execute(vm, nil)
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
@@ -10,10 +10,41 @@ import (
 type HintRunner struct {
 	// A mapping from program counter to hint implementation
 	hints map[uint64]Hinter
+	// State shared between hints along the execution
+	context *HintRunnerContext
 }
 
 func NewHintRunner(hints map[uint64]Hinter) HintRunner {
-	return HintRunner{hints}
+	return HintRunner{
+		hints:   hints,
+		context: NewHintRunnerContext(),
+	}
+}
+
+// Sets the gas the execution starts with, which Cairo 1 programs consume along the
+// way. It must be called before running any hint
+func (hr *HintRunner) SetAvailableGas(gas uint64) {
+	hr.context.SetAvailableGas(gas)
+}
+
+func (hr *HintRunner) AvailableGas() uint64 {
+	return hr.context.AvailableGas()
+}
+
+// Updates the segments tracked by hints after the memory segments were compacted,
+// given the new index of every segment, -1 for the dropped ones
+func (hr *HintRunner) RemapSegments(newIndices []int) {
+	hr.context.DictionaryManager.RemapSegments(newIndices)
+}
+
+// Serializes the state shared by hints so far, see HintRunnerContext.MarshalState
+func (hr *HintRunner) MarshalState() ([]byte, error) {
+	return hr.context.MarshalState()
+}
+
+// Restores the state shared by hints from one serialized by MarshalState
+func (hr *HintRunner) UnmarshalState(content []byte) error {
+	return hr.context.UnmarshalState(content)
 }
 
 func (hr HintRunner) RunHint(vm *VM.VirtualMachine) error {
@@ -22,7 +53,7 @@ func (hr HintRunner) RunHint(vm *VM.VirtualMachine) error {
 		return nil
 	}
 
-	err := hint.Execute(vm)
+	err := hint.Execute(vm, hr.context)
 	if err != nil {
 		return fmt.Errorf("execute hint %s: %v", hint, err)
 	}
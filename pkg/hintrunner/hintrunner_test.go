@@ -1,10 +1,13 @@
 package hintrunner
 
 import (
+	"math/big"
 	"testing"
 
+	"github.com/NethermindEth/cairo-vm-go/pkg/assembler"
 	VM "github.com/NethermindEth/cairo-vm-go/pkg/vm"
 	"github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
+	f "github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
 	"github.com/stretchr/testify/require"
 )
 
@@ -51,3 +54,195 @@ func TestNoHint(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, 2, len(vm.Memory.Segments))
 }
+
+func TestHintsShareScope(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	writeTo(vm, VM.ExecutionSegment, 0, writeSegment(vm))
+
+	var rangeCheckPtr ApCellRef = 0
+	var skipA ApCellRef = 1
+	hr := NewHintRunner(map[uint64]Hinter{
+		// arcs are p - 10, 5 and 4, so the first one is excluded
+		10: AssertLeFindSmallArcs{
+			rangeCheckPtr: Deref{rangeCheckPtr},
+			a:             Immediate(*new(big.Int).Sub(f.Modulus(), big.NewInt(10))),
+			b:             Immediate(*new(big.Int).Sub(f.Modulus(), big.NewInt(5))),
+		},
+		11: AssertLeIsFirstArcExcluded{skipExcludeAFlag: skipA},
+	})
+
+	vm.Context.Pc = memory.MemoryAddress{SegmentIndex: 0, Offset: 10}
+	err := hr.RunHint(vm)
+	require.NoError(t, err)
+
+	vm.Context.Pc = memory.MemoryAddress{SegmentIndex: 0, Offset: 11}
+	err = hr.RunHint(vm)
+	require.NoError(t, err)
+
+	require.Equal(t, memory.MemoryValueFromUint(uint64(0)), readFrom(vm, VM.ExecutionSegment, 1))
+}
+
+func TestScopeVariables(t *testing.T) {
+	ctx := NewHintRunnerContext()
+
+	_, err := ctx.GetVariable("n")
+	require.ErrorContains(t, err, "variable n not found in scope")
+
+	ctx.SetVariable("n", 1)
+	ctx.SetVariable("n", 2)
+	value, err := ctx.GetVariable("n")
+	require.NoError(t, err)
+	require.Equal(t, 2, value)
+}
+
+func TestGasMetering(t *testing.T) {
+	vm := defaultVirtualMachine()
+
+	var dst ApCellRef = 0
+	hr := NewHintRunner(map[uint64]Hinter{
+		10: ConsumeGas{amount: Immediate(*big.NewInt(30))},
+		11: GetAvailableGas{dst: dst},
+		12: ConsumeGas{amount: Immediate(*big.NewInt(70))},
+		13: ConsumeGas{amount: Immediate(*big.NewInt(1))},
+	})
+	hr.SetAvailableGas(100)
+
+	runHintAt := func(pc uint64) error {
+		vm.Context.Pc = memory.MemoryAddress{SegmentIndex: 0, Offset: pc}
+		return hr.RunHint(vm)
+	}
+
+	require.NoError(t, runHintAt(10))
+	// remaining gas is read mid run
+	require.NoError(t, runHintAt(11))
+	require.Equal(t, memory.MemoryValueFromUint(uint64(70)), readFrom(vm, VM.ExecutionSegment, 0))
+
+	// consume the remaining gas down to zero
+	require.NoError(t, runHintAt(12))
+	require.Equal(t, uint64(0), hr.context.AvailableGas())
+
+	err := runHintAt(13)
+	require.ErrorContains(t, err, "execute hint ConsumeGas: out of gas: 1 needed but only 0 available")
+}
+
+func TestConsumeGasKeepsGasOnError(t *testing.T) {
+	ctx := NewHintRunnerContext()
+	ctx.SetAvailableGas(5)
+
+	err := ctx.ConsumeGas(6)
+	require.ErrorContains(t, err, "out of gas: 6 needed but only 5 available")
+	require.Equal(t, uint64(5), ctx.AvailableGas())
+
+	require.NoError(t, ctx.ConsumeGas(5))
+	require.Equal(t, uint64(0), ctx.AvailableGas())
+}
+
+func TestRemapDictionarySegments(t *testing.T) {
+	vm := defaultVirtualMachine()
+	hr := NewHintRunner(nil)
+	dm := &hr.context.DictionaryManager
+
+	// the first dictionary is never accessed, so its segment is left empty
+	dm.NewDictionary(vm, memory.MemoryValueFromInt(0))
+	used := dm.NewDictionary(vm, memory.MemoryValueFromInt(0))
+	key := memory.MemoryValueFromInt(3)
+	require.NoError(t, vm.Memory.WriteToAddress(&used, &key))
+
+	dict, err := dm.GetDictionary(&used)
+	require.NoError(t, err)
+
+	newIndices := vm.Memory.CompactSegments()
+	hr.RemapSegments(newIndices)
+
+	// only the accessed dictionary is kept, its segment moved down to the one of the
+	// dropped dictionary
+	require.Len(t, dm.dictionaries, 1)
+	_, err = dm.GetDictionary(&used)
+	require.ErrorContains(t, err, "no dictionary at segment 3")
+
+	remapped := memory.MemoryAddress{SegmentIndex: 2, Offset: 0}
+	remappedDict, err := dm.GetDictionary(&remapped)
+	require.NoError(t, err)
+	require.Same(t, dict, remappedDict)
+	require.Equal(t, uint64(2), remappedDict.SegmentIndex())
+	require.Equal(t, key, readFrom(vm, 2, 0))
+}
+
+func TestResumeRunWithHintState(t *testing.T) {
+	bytecode, err := assembler.CasmToBytecode(`
+        ap += 1;
+        ap += 1;
+        ap += 2;
+        ap += 1;
+        ap += 1;
+        ap += 1;
+        ap += 2;
+        ap += 1;
+        ap += 1;
+        jmp rel 0;
+    `)
+	require.NoError(t, err)
+
+	// every instruction takes two cells, hints before the snapshot fill the state
+	// the hints after it depend on
+	hints := map[uint64]Hinter{
+		0: Felt252DictNew{dst: ApCellRef(0)},
+		2: Felt252DictWrite{
+			dictPtr:      Deref{ApCellRef(-1)},
+			key:          Immediate(*big.NewInt(5)),
+			value:        Immediate(*big.NewInt(9)),
+			prevValueDst: ApCellRef(0),
+		},
+		4: RandomEcPoint{x: ApCellRef(0), y: ApCellRef(1)},
+		6: RecordAp{},
+		8: ConsumeGas{amount: Immediate(*big.NewInt(30))},
+		// snapshot
+		10: Felt252DictRead{
+			dictPtr:  Deref{ApCellRef(-6)},
+			key:      Immediate(*big.NewInt(5)),
+			valueDst: ApCellRef(0),
+		},
+		12: RandomEcPoint{x: ApCellRef(0), y: ApCellRef(1)},
+		14: AssertApDelta{delta: 5},
+		16: GetAvailableGas{dst: ApCellRef(0)},
+	}
+	newRun := func() (*VM.VirtualMachine, HintRunner) {
+		memory := memory.InitializeEmptyMemory()
+		_, err := memory.AllocateSegment(bytecode)
+		require.NoError(t, err)
+		memory.AllocateEmptySegment()
+
+		vm, err := VM.NewVirtualMachine(VM.Context{Ap: 1, Fp: 1}, memory, VM.VirtualMachineConfig{})
+		require.NoError(t, err)
+		hr := NewHintRunner(hints)
+		hr.SetAvailableGas(100)
+		return vm, hr
+	}
+	runSteps := func(vm *VM.VirtualMachine, hr HintRunner, steps int) {
+		for i := 0; i < steps; i++ {
+			require.NoError(t, vm.RunStep(hr))
+		}
+	}
+
+	uninterrupted, uninterruptedHr := newRun()
+	runSteps(uninterrupted, uninterruptedHr, 9)
+
+	interrupted, interruptedHr := newRun()
+	runSteps(interrupted, interruptedHr, 5)
+	vmState := interrupted.MarshalState()
+	hintState, err := interruptedHr.MarshalState()
+	require.NoError(t, err)
+
+	resumed, resumedHr := newRun()
+	require.NoError(t, resumed.UnmarshalState(vmState))
+	require.NoError(t, resumedHr.UnmarshalState(hintState))
+	runSteps(resumed, resumedHr, 4)
+
+	require.Equal(t, uninterrupted.Context, resumed.Context)
+	require.Equal(t, uninterrupted.Memory.Segments, resumed.Memory.Segments)
+	require.Equal(t, uninterruptedHr.context, resumedHr.context)
+	// the dictionary value and the remaining gas were read from the restored state
+	require.Equal(t, memory.MemoryValueFromInt(9), readFrom(resumed, VM.ExecutionSegment, 7))
+	require.Equal(t, memory.MemoryValueFromInt(70), readFrom(resumed, VM.ExecutionSegment, 11))
+}
@@ -0,0 +1,125 @@
+package hintrunner
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+
+	"github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
+	f "github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+)
+
+func init() {
+	// types hints store in the scope besides the basic ones, which gob needs to know
+	// to encode them behind an interface
+	gob.Register(f.Element{})
+	gob.Register([]f.Element{})
+	gob.Register(map[f.Element][]uint64{})
+}
+
+// Snapshot of a HintRunnerContext with every field exported, as gob requires
+type contextState struct {
+	Scope        map[string]any
+	Dictionaries []dictionaryState
+	PreviousAp   *uint64
+	AvailableGas uint64
+}
+
+type dictionaryState struct {
+	Data         map[f.Element]memoryValueState
+	DefaultValue memoryValueState
+	SegmentIndex uint64
+	AccessCount  uint64
+	Squashed     bool
+}
+
+type memoryValueState struct {
+	Known     bool
+	IsAddress bool
+	Address   memory.MemoryAddress
+	Felt      f.Element
+}
+
+// Serializes the state hints shared so far, so that the run can be resumed later by
+// calling UnmarshalState. The state of the virtual machine is serialized apart
+func (ctx *HintRunnerContext) MarshalState() ([]byte, error) {
+	state := contextState{
+		Scope:        ctx.scope,
+		Dictionaries: make([]dictionaryState, 0, len(ctx.DictionaryManager.dictionaries)),
+		PreviousAp:   ctx.previousAp,
+		AvailableGas: ctx.availableGas,
+	}
+	for _, dict := range ctx.DictionaryManager.dictionaries {
+		dictState := dictionaryState{
+			Data:         make(map[f.Element]memoryValueState, len(dict.data)),
+			DefaultValue: newMemoryValueState(&dict.defaultValue),
+			SegmentIndex: dict.segmentIndex,
+			AccessCount:  dict.accessCount,
+			Squashed:     dict.squashed,
+		}
+		for key, value := range dict.data {
+			dictState.Data[key] = newMemoryValueState(&value)
+		}
+		state.Dictionaries = append(state.Dictionaries, dictState)
+	}
+
+	var content bytes.Buffer
+	if err := gob.NewEncoder(&content).Encode(&state); err != nil {
+		return nil, fmt.Errorf("encode hint context: %w", err)
+	}
+	return content.Bytes(), nil
+}
+
+// Replaces the state of the context with one serialized by MarshalState
+func (ctx *HintRunnerContext) UnmarshalState(content []byte) error {
+	state := contextState{}
+	if err := gob.NewDecoder(bytes.NewReader(content)).Decode(&state); err != nil {
+		return fmt.Errorf("decode hint context: %w", err)
+	}
+
+	dictionaries := make(map[uint64]*Dictionary, len(state.Dictionaries))
+	for _, dictState := range state.Dictionaries {
+		dict := &Dictionary{
+			data:         make(map[f.Element]memory.MemoryValue, len(dictState.Data)),
+			defaultValue: dictState.DefaultValue.memoryValue(),
+			segmentIndex: dictState.SegmentIndex,
+			accessCount:  dictState.AccessCount,
+			squashed:     dictState.Squashed,
+		}
+		for key, value := range dictState.Data {
+			dict.data[key] = value.memoryValue()
+		}
+		dictionaries[dict.segmentIndex] = dict
+	}
+
+	ctx.scope = state.Scope
+	if ctx.scope == nil {
+		ctx.scope = make(map[string]any)
+	}
+	ctx.DictionaryManager.dictionaries = dictionaries
+	ctx.previousAp = state.PreviousAp
+	ctx.availableGas = state.AvailableGas
+	return nil
+}
+
+func newMemoryValueState(value *memory.MemoryValue) memoryValueState {
+	state := memoryValueState{Known: value.Known(), IsAddress: value.IsAddress()}
+	if address, err := value.MemoryAddress(); err == nil {
+		state.Address = *address
+	}
+	if felt, err := value.FieldElement(); err == nil {
+		state.Felt = *felt
+	}
+	return state
+}
+
+func (state *memoryValueState) memoryValue() memory.MemoryValue {
+	switch {
+	case !state.Known:
+		return memory.UnknownValue
+	case state.IsAddress:
+		return memory.MemoryValueFromMemoryAddress(&state.Address)
+	default:
+		return memory.MemoryValueFromFieldElement(&state.Felt)
+	}
+}
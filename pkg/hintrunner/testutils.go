@@ -1,9 +1,12 @@
 package hintrunner
 
 import (
+	"math/big"
+
 	"github.com/NethermindEth/cairo-vm-go/pkg/vm"
 	VM "github.com/NethermindEth/cairo-vm-go/pkg/vm"
 	"github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
+	"golang.org/x/exp/constraints"
 )
 
 func defaultVirtualMachine() *vm.VirtualMachine {
@@ -32,3 +35,47 @@ func readFrom(vm *VM.VirtualMachine, segment uint64, offset uint64) memory.Memor
 	}
 	return val
 }
+
+// Allocates a new segment containing the given values and returns a pointer to its start
+func writeSegment(vm *VM.VirtualMachine, values ...memory.MemoryValue) memory.MemoryValue {
+	segment := vm.Memory.AllocateEmptySegment()
+	for i := range values {
+		writeTo(vm, uint64(segment), uint64(i), values[i])
+	}
+	return memory.MemoryValueFromSegmentAndOffset(segment, 0)
+}
+
+// Converts integers into the memory values of a range read by a hint
+func intValues[T constraints.Integer](values ...T) []memory.MemoryValue {
+	memoryValues := make([]memory.MemoryValue, len(values))
+	for i, v := range values {
+		memoryValues[i] = memory.MemoryValueFromInt(v)
+	}
+	return memoryValues
+}
+
+// Allocates a segment for each of the given ranges and writes a pointer to the i-th one
+// at [ap + i], returning the operands dereferencing those pointers in order
+func writeRanges(vm *VM.VirtualMachine, ranges ...[]memory.MemoryValue) []ResOperander {
+	operands := make([]ResOperander, len(ranges))
+	for i, values := range ranges {
+		writeTo(vm, VM.ExecutionSegment, vm.Context.Ap+uint64(i), writeSegment(vm, values...))
+		operands[i] = Deref{ApCellRef(i)}
+	}
+	return operands
+}
+
+// Splits an u384 value into four 96 bits limbs, from the least significant one,
+// as immediate operands
+func uint384Immediates(value *big.Int) [4]ResOperander {
+	mask := new(big.Int).Lsh(big.NewInt(1), 96)
+	mask.Sub(mask, big.NewInt(1))
+
+	limbs := [4]ResOperander{}
+	rest := new(big.Int).Set(value)
+	for i := range limbs {
+		limbs[i] = Immediate(*new(big.Int).And(rest, mask))
+		rest.Rsh(rest, 96)
+	}
+	return limbs
+}
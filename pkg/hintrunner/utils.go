@@ -0,0 +1,210 @@
+package hintrunner
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	VM "github.com/NethermindEth/cairo-vm-go/pkg/vm"
+	"github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
+	f "github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+)
+
+// Resolves an operand and returns its value as a field element
+func resolveAsFelt(vm *VM.VirtualMachine, operand ResOperander) (*f.Element, error) {
+	value, err := operand.Resolve(vm)
+	if err != nil {
+		return nil, err
+	}
+	return value.FieldElement()
+}
+
+// Resolves an operand and returns its value as a memory address
+func resolveAsAddress(vm *VM.VirtualMachine, operand ResOperander) (*memory.MemoryAddress, error) {
+	value, err := operand.Resolve(vm)
+	if err != nil {
+		return nil, err
+	}
+	return value.MemoryAddress()
+}
+
+// Resolves an operand and returns its value as an uint64, errors if it doesn't fit
+func resolveAsUint64(vm *VM.VirtualMachine, operand ResOperander) (uint64, error) {
+	value, err := operand.Resolve(vm)
+	if err != nil {
+		return 0, err
+	}
+	return value.Uint64()
+}
+
+// Writes a memory value to the cell referenced by dst
+func writeValue(vm *VM.VirtualMachine, dst CellRefer, mv *memory.MemoryValue) error {
+	dstAddr, err := dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell %s: %w", dst, err)
+	}
+
+	err = vm.Memory.WriteToAddress(&dstAddr, mv)
+	if err != nil {
+		return fmt.Errorf("write to destination cell %s: %w", dstAddr, err)
+	}
+	return nil
+}
+
+// Writes a field element to the cell referenced by dst
+func writeFelt(vm *VM.VirtualMachine, dst CellRefer, felt *f.Element) error {
+	mv := memory.MemoryValueFromFieldElement(felt)
+	return writeValue(vm, dst, &mv)
+}
+
+// Writes a memory address to the cell referenced by dst
+func writeAddress(vm *VM.VirtualMachine, dst CellRefer, address *memory.MemoryAddress) error {
+	mv := memory.MemoryValueFromMemoryAddress(address)
+	return writeValue(vm, dst, &mv)
+}
+
+// Resolves the low and high limbs of an u256 and combines them into a single big int.
+// Errors if any of the limbs doesn't fit in 128 bits
+func resolveAsUint256(vm *VM.VirtualMachine, low, high ResOperander) (*big.Int, error) {
+	lowFelt, err := resolveAsFelt(vm, low)
+	if err != nil {
+		return nil, fmt.Errorf("resolve low limb %s: %w", low, err)
+	}
+	highFelt, err := resolveAsFelt(vm, high)
+	if err != nil {
+		return nil, fmt.Errorf("resolve high limb %s: %w", high, err)
+	}
+
+	lowBig := lowFelt.BigInt(new(big.Int))
+	highBig := highFelt.BigInt(new(big.Int))
+	if lowBig.BitLen() > 128 {
+		return nil, fmt.Errorf("low limb %s should be u128", lowFelt)
+	}
+	if highBig.BitLen() > 128 {
+		return nil, fmt.Errorf("high limb %s should be u128", highFelt)
+	}
+
+	return highBig.Lsh(highBig, 128).Or(highBig, lowBig), nil
+}
+
+// Splits an u256 value into its low and high 128 bits limbs and writes them
+func writeUint256(vm *VM.VirtualMachine, low, high CellRefer, value *big.Int) error {
+	mask := new(big.Int).Lsh(big.NewInt(1), 128)
+	mask.Sub(mask, big.NewInt(1))
+
+	lowFelt := f.Element{}
+	lowFelt.SetBigInt(new(big.Int).And(value, mask))
+	if err := writeFelt(vm, low, &lowFelt); err != nil {
+		return err
+	}
+
+	highFelt := f.Element{}
+	highFelt.SetBigInt(new(big.Int).Rsh(value, 128))
+	return writeFelt(vm, high, &highFelt)
+}
+
+// Reads length consecutive field elements starting at the given address
+func readFelts(vm *VM.VirtualMachine, address *memory.MemoryAddress, length uint64) ([]*f.Element, error) {
+	felts := make([]*f.Element, length)
+	for i := uint64(0); i < length; i++ {
+		mv, err := vm.Memory.Read(address.SegmentIndex, address.Offset+i)
+		if err != nil {
+			return nil, fmt.Errorf("read cell %d: %w", i, err)
+		}
+		felt, err := mv.FieldElement()
+		if err != nil {
+			return nil, fmt.Errorf("read cell %d: %w", i, err)
+		}
+		felts[i] = felt
+	}
+	return felts, nil
+}
+
+// Base of the rolling checksum computed by the checksum hints
+const checksumBase = 31
+
+// Computes the rolling checksum c = c * 31 + v over the values, in the field
+func rollingChecksum(values []*f.Element) f.Element {
+	base := f.NewElement(checksumBase)
+	checksum := f.Element{}
+	for _, value := range values {
+		checksum.Mul(&checksum, &base)
+		checksum.Add(&checksum, value)
+	}
+	return checksum
+}
+
+// Resolves the four 96 bits limbs of an u384, from the least significant one, and
+// combines them into a single big int. Errors if any of the limbs doesn't fit in 96 bits
+func resolveAsUint384(vm *VM.VirtualMachine, limbs [4]ResOperander) (*big.Int, error) {
+	value := new(big.Int)
+	for i := len(limbs) - 1; i >= 0; i-- {
+		limb, err := resolveAsFelt(vm, limbs[i])
+		if err != nil {
+			return nil, fmt.Errorf("resolve limb %d %s: %w", i, limbs[i], err)
+		}
+		limbBig := limb.BigInt(new(big.Int))
+		if limbBig.BitLen() > 96 {
+			return nil, fmt.Errorf("limb %d %s should be u96", i, limb)
+		}
+		value.Lsh(value, 96).Or(value, limbBig)
+	}
+	return value, nil
+}
+
+// Splits an u384 value into its four 96 bits limbs, from the least significant one,
+// and writes them
+func writeUint384(vm *VM.VirtualMachine, limbs [4]CellRefer, value *big.Int) error {
+	mask := new(big.Int).Lsh(big.NewInt(1), 96)
+	mask.Sub(mask, big.NewInt(1))
+
+	rest := new(big.Int).Set(value)
+	for i := range limbs {
+		limb := f.Element{}
+		limb.SetBigInt(new(big.Int).And(rest, mask))
+		if err := writeFelt(vm, limbs[i], &limb); err != nil {
+			return err
+		}
+		rest.Rsh(rest, 96)
+	}
+	return nil
+}
+
+// Round constants of the sha256 compression function
+var sha256RoundConstants = [64]uint32{
+	0x428a2f98, 0x71374491, 0xb5c0fbcf, 0xe9b5dba5, 0x3956c25b, 0x59f111f1, 0x923f82a4, 0xab1c5ed5,
+	0xd807aa98, 0x12835b01, 0x243185be, 0x550c7dc3, 0x72be5d74, 0x80deb1fe, 0x9bdc06a7, 0xc19bf174,
+	0xe49b69c1, 0xefbe4786, 0x0fc19dc6, 0x240ca1cc, 0x2de92c6f, 0x4a7484aa, 0x5cb0a9dc, 0x76f988da,
+	0x983e5152, 0xa831c66d, 0xb00327c8, 0xbf597fc7, 0xc6e00bf3, 0xd5a79147, 0x06ca6351, 0x14292967,
+	0x27b70a85, 0x2e1b2138, 0x4d2c6dfc, 0x53380d13, 0x650a7354, 0x766a0abb, 0x81c2c92e, 0x92722c85,
+	0xa2bfe8a1, 0xa81a664b, 0xc24b8b70, 0xc76c51a3, 0xd192e819, 0xd6990624, 0xf40e3585, 0x106aa070,
+	0x19a4c116, 0x1e376c08, 0x2748774c, 0x34b0bcb5, 0x391c0cb3, 0x4ed8aa4a, 0x5b9cca4f, 0x682e6ff3,
+	0x748f82ee, 0x78a5636f, 0x84c87814, 0x8cc70208, 0x90befffa, 0xa4506ceb, 0xbef9a3f7, 0xc67178f2,
+}
+
+// Applies the sha256 compression function to a state with a single 16 words block
+func sha256Compress(state *[8]uint32, block *[16]uint32) {
+	var w [64]uint32
+	copy(w[:], block[:])
+	for i := 16; i < 64; i++ {
+		s0 := bits.RotateLeft32(w[i-15], -7) ^ bits.RotateLeft32(w[i-15], -18) ^ (w[i-15] >> 3)
+		s1 := bits.RotateLeft32(w[i-2], -17) ^ bits.RotateLeft32(w[i-2], -19) ^ (w[i-2] >> 10)
+		w[i] = w[i-16] + s0 + w[i-7] + s1
+	}
+
+	// working variables a to h
+	v := *state
+	for i := 0; i < 64; i++ {
+		s1 := bits.RotateLeft32(v[4], -6) ^ bits.RotateLeft32(v[4], -11) ^ bits.RotateLeft32(v[4], -25)
+		ch := (v[4] & v[5]) ^ (^v[4] & v[6])
+		t1 := v[7] + s1 + ch + sha256RoundConstants[i] + w[i]
+		s0 := bits.RotateLeft32(v[0], -2) ^ bits.RotateLeft32(v[0], -13) ^ bits.RotateLeft32(v[0], -22)
+		maj := (v[0] & v[1]) ^ (v[0] & v[2]) ^ (v[1] & v[2])
+
+		v = [8]uint32{t1 + s0 + maj, v[0], v[1], v[2], v[3] + t1, v[4], v[5], v[6]}
+	}
+
+	for i := range state {
+		state[i] += v[i]
+	}
+}
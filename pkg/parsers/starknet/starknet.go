@@ -23,6 +23,30 @@ const (
 	SegmentArena
 )
 
+func (b Builtin) String() string {
+	switch b {
+	case Output:
+		return "output"
+	case RangeCheck:
+		return "range_check"
+	case Pedersen:
+		return "pedersen"
+	case ECDSA:
+		return "ecdsa"
+	case Keccak:
+		return "keccak"
+	case Bitwise:
+		return "bitwise"
+	case ECOP:
+		return "ec_op"
+	case Poseidon:
+		return "poseidon"
+	case SegmentArena:
+		return "segment_arena"
+	}
+	return fmt.Sprintf("unknown builtin %d", uint8(b))
+}
+
 func (b Builtin) MarshalJSON() ([]byte, error) {
 	switch b {
 	case Output:
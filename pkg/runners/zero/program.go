@@ -7,6 +7,7 @@ import (
 	sn "github.com/NethermindEth/cairo-vm-go/pkg/parsers/starknet"
 	"github.com/NethermindEth/cairo-vm-go/pkg/parsers/zero"
 	f "github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+	pedersenhash "github.com/consensys/gnark-crypto/ecc/stark-curve/pedersen-hash"
 )
 
 type Program struct {
@@ -57,6 +58,42 @@ func LoadCairoZeroProgram(content []byte) (*Program, error) {
 	}, nil
 }
 
+// Computes the program hash as the Pedersen hash chain over the program header and its
+// bytecode. It matches `compute_program_hash_chain` from the reference implementation
+// using a bootloader version of zero
+func (program *Program) Hash() (*f.Element, error) {
+	mainPc, ok := program.Entrypoints["main"]
+	if !ok {
+		return nil, errors.New("unknown entrypoint: main")
+	}
+
+	// header: bootloader version, main pc, amount of builtins and their names
+	chain := []*f.Element{
+		new(f.Element),
+		new(f.Element).SetUint64(mainPc),
+		new(f.Element).SetUint64(uint64(len(program.Builtins))),
+	}
+	for _, builtin := range program.Builtins {
+		chain = append(chain, new(f.Element).SetBytes([]byte(builtin.String())))
+	}
+	chain = append(chain, program.Bytecode...)
+	// the chain is prefixed by its own length
+	chain = append([]*f.Element{new(f.Element).SetUint64(uint64(len(chain)))}, chain...)
+
+	hash := computeHashChain(chain)
+	return &hash, nil
+}
+
+// Computes h(c_0, h(c_1, ... h(c_n-1, c_n))) with the Pedersen hash, as
+// `compute_hash_chain` from the reference implementation does
+func computeHashChain(chain []*f.Element) f.Element {
+	hash := *chain[len(chain)-1]
+	for i := len(chain) - 2; i >= 0; i-- {
+		hash = pedersenhash.Pedersen(chain[i], &hash)
+	}
+	return hash
+}
+
 func extractEntrypoints(json *zero.ZeroProgram) (map[string]uint64, error) {
 	result := make(map[string]uint64)
 	err := scanIdentifiers(
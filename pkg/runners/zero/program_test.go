@@ -1,9 +1,12 @@
 package zero
 
 import (
+	"testing"
+
+	sn "github.com/NethermindEth/cairo-vm-go/pkg/parsers/starknet"
 	f "github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+	pedersenhash "github.com/consensys/gnark-crypto/ecc/stark-curve/pedersen-hash"
 	"github.com/stretchr/testify/require"
-	"testing"
 )
 
 func TestLoadCairoZeroProgram(t *testing.T) {
@@ -58,3 +61,72 @@ func TestLoadCairoZeroProgram(t *testing.T) {
 		program,
 	)
 }
+
+func TestComputeHashChain(t *testing.T) {
+	felt := func(hex string) *f.Element {
+		felt, err := new(f.Element).SetString(hex)
+		if err != nil {
+			panic(err)
+		}
+		return felt
+	}
+
+	// Pedersen test vectors from cairo-lang `signature_test_data.json`, a chain of two
+	// elements being a single hash of them in order
+	testCases := [][3]string{
+		{
+			"0x3d937c035c878245caf64531a5756109c53068da139362728feb561405371cb",
+			"0x208a0a10250e382e1e4bbe2880906c2791bf6275695e02fbbc6aeff9cd8b31a",
+			"0x30e480bed5fe53fa909cc0f8c4d99b8f9f2c016be4c41e13a4848797979c662",
+		},
+		{
+			"0x58f580910a6ca59b28927c08fe6c43e2e303ca384badc365795fc645d479d45",
+			"0x78734f65a067be9bdb39de18434d71e79f7b6466a4b66bbd979ab9e7515fe0b",
+			"0x68cc0b76cddd1dd4ed2301ada9b7c872b23875d5ff837b3a87993e0d9996b87",
+		},
+	}
+	for _, tc := range testCases {
+		hash := computeHashChain([]*f.Element{felt(tc[0]), felt(tc[1])})
+		require.Equal(t, felt(tc[2]), &hash)
+	}
+
+	// the chain is folded from its end
+	a, b, c := felt(testCases[0][0]), felt(testCases[0][1]), felt(testCases[1][0])
+	inner := pedersenhash.Pedersen(b, c)
+	expected := pedersenhash.Pedersen(a, &inner)
+	require.Equal(t, expected, computeHashChain([]*f.Element{a, b, c}))
+}
+
+func TestProgramHash(t *testing.T) {
+	felt := func(v uint64) *f.Element {
+		return new(f.Element).SetUint64(v)
+	}
+	program := &Program{
+		Bytecode:    []*f.Element{felt(1), felt(2), felt(3)},
+		Entrypoints: map[string]uint64{"main": 2},
+		Builtins:    []sn.Builtin{sn.Output},
+	}
+
+	hash, err := program.Hash()
+	require.NoError(t, err)
+
+	// the chain `compute_program_hash_chain` builds: its length, then the bootloader
+	// version, the main pc, the amount of builtins, their names encoded as ascii and
+	// the bytecode
+	output, err := new(f.Element).SetString("0x6f7574707574")
+	require.NoError(t, err)
+	expected := computeHashChain([]*f.Element{
+		felt(7), felt(0), felt(2), felt(1), output, felt(1), felt(2), felt(3),
+	})
+	require.Equal(t, &expected, hash)
+}
+
+func TestProgramHashWithoutMain(t *testing.T) {
+	program := &Program{
+		Bytecode:    []*f.Element{new(f.Element).SetOne()},
+		Entrypoints: map[string]uint64{"fib": 0},
+	}
+
+	_, err := program.Hash()
+	require.ErrorContains(t, err, "unknown entrypoint: main")
+}
@@ -1,16 +1,18 @@
 package zero
 
 import (
+	"bytes"
+	"encoding/gob"
 	"errors"
 	"fmt"
 
 	"github.com/NethermindEth/cairo-vm-go/pkg/hintrunner"
+	sn "github.com/NethermindEth/cairo-vm-go/pkg/parsers/starknet"
 	"github.com/NethermindEth/cairo-vm-go/pkg/safemath"
 	"github.com/NethermindEth/cairo-vm-go/pkg/vm"
 	"github.com/NethermindEth/cairo-vm-go/pkg/vm/builtins"
 	mem "github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
 	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
-	f "github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
 )
 
 type ZeroRunner struct {
@@ -20,13 +22,25 @@ type ZeroRunner struct {
 	hintrunner hintrunner.HintRunner
 	// config
 	proofmode bool
+	secureRun bool
 	maxsteps  uint64
+	options   RunOptions
+	// ecdsa builtin holding the signatures registered before the run
+	ecdsa *builtins.Ecdsa
+	// where the run starts, which is past the previous runs when the vm is shared
+	// with them, see RunMany
+	programSegment uint64
+	initialFp      uint64
+	initialStep    uint64
+	outputStart    uint64
 	// auxiliar
 	runFinished bool
 }
 
-// Creates a new Runner of a Cairo Zero program
-func NewRunner(program *Program, proofmode bool, maxsteps uint64) (ZeroRunner, error) {
+// Creates a new Runner of a Cairo Zero program. When secureRun is set, memory is
+// validated once the execution finishes. The execution errors once it takes maxsteps
+// steps, unless maxsteps is 0 in which case it is unbounded
+func NewRunner(program *Program, proofmode bool, secureRun bool, maxsteps uint64) (ZeroRunner, error) {
 	// todo(rodro): given the program get the appropiate hints
 	hintrunner := hintrunner.NewHintRunner(make(map[uint64]hintrunner.Hinter))
 
@@ -34,12 +48,56 @@ func NewRunner(program *Program, proofmode bool, maxsteps uint64) (ZeroRunner, e
 		program:    program,
 		hintrunner: hintrunner,
 		proofmode:  proofmode,
+		secureRun:  secureRun,
 		maxsteps:   maxsteps,
 	}, nil
 }
 
-// todo(rodro): should we add support for running any function?
+// Customizes how a run starts. Only AvailableGas applies in proof mode
+type RunOptions struct {
+	// Name of the function to run, main when empty
+	Entrypoint string
+	// Arguments pushed to the stack right after the builtin pointers
+	Arguments []mem.MemoryValue
+	// Initial fp as an offset of the execution segment. The stack is written right
+	// before it, so it must leave room for it. By default the stack is written at the
+	// start of the execution segment and fp points right after it
+	InitialFp *uint64
+	// Initial ap as an offset of the execution segment, by default it equals fp
+	InitialAp *uint64
+	// Gas the execution starts with, consumed by the gas hints of Cairo 1 programs
+	AvailableGas uint64
+}
+
+// Sets the options the next run starts with
+func (runner *ZeroRunner) SetRunOptions(options RunOptions) {
+	runner.options = options
+}
+
+// Registers the (r, s) signature the ecdsa builtin verifies for the instance whose
+// public key is at pubKeyOffset of the builtin segment. It must be called before the run
+func (runner *ZeroRunner) AddEcdsaSignature(pubKeyOffset uint64, r, s *fp.Element) error {
+	if runner.vm != nil {
+		return errors.New("cannot add a signature once the run started")
+	}
+	if runner.ecdsa == nil {
+		runner.ecdsa = &builtins.Ecdsa{}
+	}
+	return runner.ecdsa.AddSignature(pubKeyOffset, r, s)
+}
+
 func (runner *ZeroRunner) Run() error {
+	if err := runner.runUnpadded(); err != nil {
+		return err
+	}
+	if runner.proofmode {
+		return runner.padTrace()
+	}
+	return nil
+}
+
+// Runs main and checks the execution, leaving the proof mode trace unpadded
+func (runner *ZeroRunner) runUnpadded() error {
 	if runner.runFinished {
 		return errors.New("cannot re-run using the same runner")
 	}
@@ -54,25 +112,252 @@ func (runner *ZeroRunner) Run() error {
 		return err
 	}
 
+	if err := runner.checkEcdsaSignatures(); err != nil {
+		return fmt.Errorf("ecdsa builtin: %w", err)
+	}
+
 	if runner.proofmode {
-		// +1 because proof mode require an extra instruction run
-		// pow2 because proof mode also requires that the trace is a power of two
-		pow2Steps := safemath.NextPowerOfTwo(runner.vm.Step + 1)
-		if err := runner.RunFor(pow2Steps); err != nil {
-			return err
+		builtinPointers, err := runner.checkFinalStack()
+		if err != nil {
+			return fmt.Errorf("final stack: %w", err)
+		}
+		if err := runner.VerifyBuiltinPointers(builtinPointers); err != nil {
+			return fmt.Errorf("builtin pointers: %w", err)
+		}
+	}
+
+	if runner.secureRun {
+		if err := runner.VerifySecureRun(); err != nil {
+			return fmt.Errorf("secure run: %w", err)
+		}
+	}
+	return nil
+}
+
+// Repeats the step looping at `__end__` until the trace, which holds the steps of every
+// run sharing the vm, is as long as proof mode requires
+func (runner *ZeroRunner) padTrace() error {
+	// +1 because proof mode require an extra instruction run
+	// pow2 because proof mode also requires that the trace is a power of two
+	pow2Steps := safemath.NextPowerOfTwo(runner.vm.Step + 1)
+	return runner.RunFor(pow2Steps - runner.initialStep)
+}
+
+// Outcome of one of the programs executed by RunMany
+type RunResult struct {
+	Output []*fp.Element
+	// Steps taken by the program, leaving out the padding of the combined trace
+	Steps uint64
+}
+
+// Runs several programs one after the other with the same configuration, as needed
+// when aggregating their proofs. They all run on the same vm: each program is loaded
+// in its own segment, while the execution segment and the builtin segments are shared,
+// every program carrying on from where the previous one left them. It returns the
+// result of every program in the same order and, in proof mode, the combined trace and
+// memory relocated at once so that the addresses of the programs never collide. The
+// combined trace is padded once, after the last program, to a power of two length
+func RunMany(
+	programs []*Program, proofmode bool, secureRun bool, maxsteps uint64,
+) ([]RunResult, []vm.Trace, []*fp.Element, error) {
+	results := make([]RunResult, 0, len(programs))
+	var runner ZeroRunner
+	var machine *vm.VirtualMachine
+	for i, program := range programs {
+		var err error
+		runner, err = NewRunner(program, proofmode, secureRun, maxsteps)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("program %d: %w", i, err)
+		}
+		// the first program initializes the vm the others run on
+		runner.vm = machine
+		if err := runner.runUnpadded(); err != nil {
+			return nil, nil, nil, fmt.Errorf("program %d: %w", i, err)
+		}
+		machine = runner.vm
+
+		results = append(results, RunResult{
+			Output: runner.Output(),
+			Steps:  runner.steps(),
+		})
+	}
+
+	if !proofmode || machine == nil {
+		return results, nil, nil, nil
+	}
+	// the last program keeps looping at its `__end__` to pad the combined trace
+	if err := runner.padTrace(); err != nil {
+		return nil, nil, nil, fmt.Errorf("program %d: %w", len(programs)-1, err)
+	}
+	trace, err := machine.RelocateTrace()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	memory, err := machine.RelocateMemory()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return results, trace, memory, nil
+}
+
+// Errors if the program wrote an ecdsa instance whose signature was never registered
+func (runner *ZeroRunner) checkEcdsaSignatures() error {
+	ecdsa, ok := runner.ecdsaRunner()
+	if !ok {
+		return nil
+	}
+	return ecdsa.CheckSignatures()
+}
+
+// Checks the registers once main has returned to `__end__` while running in proof mode,
+// returning the builtin pointers main returned. At that point fp must be back to its
+// initial value, and main return values, one pointer per builtin in the same order as
+// the program builtins, must lay right before ap
+func (runner *ZeroRunner) checkFinalStack() ([]*mem.MemoryAddress, error) {
+	if runner.vm.Context.Fp != runner.initialFp {
+		return nil, fmt.Errorf(
+			"final fp %d differs from the initial fp %d",
+			runner.vm.Context.Fp, runner.initialFp,
+		)
+	}
+
+	builtinsCount := uint64(len(runner.program.Builtins))
+	if runner.vm.Context.Ap < runner.initialFp+builtinsCount {
+		return nil, fmt.Errorf(
+			"final ap %d leaves no room for %d builtin pointers",
+			runner.vm.Context.Ap, builtinsCount,
+		)
+	}
+
+	return runner.finalBuiltinPointers()
+}
+
+// Reads main return values, one pointer per builtin in the same order as the program
+// builtins laying right before ap, erroring if any of them isn't a pointer to the
+// segment of its builtin
+func (runner *ZeroRunner) finalBuiltinPointers() ([]*mem.MemoryAddress, error) {
+	builtinsCount := uint64(len(runner.program.Builtins))
+	if runner.vm.Context.Ap < builtinsCount {
+		return nil, fmt.Errorf(
+			"final ap %d leaves no room for %d builtin pointers",
+			runner.vm.Context.Ap, builtinsCount,
+		)
+	}
+
+	returnValuesStart := runner.vm.Context.Ap - builtinsCount
+	pointers := make([]*mem.MemoryAddress, builtinsCount)
+	for i, builtin := range runner.program.Builtins {
+		offset := returnValuesStart + uint64(i)
+		value, err := runner.vm.Memory.Peek(vm.ExecutionSegment, offset)
+		if err != nil {
+			return nil, err
+		}
+
+		address, err := value.MemoryAddress()
+		if err != nil || address.SegmentIndex >= uint64(len(runner.vm.Memory.Segments)) ||
+			runner.vm.Memory.Segments[address.SegmentIndex].BuiltinRunner.String() != builtin.String() {
+			return nil, fmt.Errorf(
+				"final ap %d: expected a pointer to the %s builtin at offset %d but got %s",
+				runner.vm.Context.Ap, builtin, offset, value,
+			)
+		}
+		pointers[i] = address
+	}
+	return pointers, nil
+}
+
+// Checks that every builtin pointer only advanced during the execution: the final
+// pointer main returns for each builtin, as read by checkFinalStack, can't be behind
+// the highest cell used in the builtin segment, otherwise the program handed some
+// instances back after using them
+func (runner *ZeroRunner) VerifyBuiltinPointers(pointers []*mem.MemoryAddress) error {
+	for i, pointer := range pointers {
+		used := runner.vm.Memory.Segments[pointer.SegmentIndex].Len()
+		if pointer.Offset < used {
+			return fmt.Errorf(
+				"%s builtin pointer went backward: final offset %d but %d cells are used",
+				runner.program.Builtins[i], pointer.Offset, used,
+			)
 		}
 	}
 	return nil
 }
 
+// Validates the memory after the execution has finished, mirroring the reference VM
+// `verify_secure_runner`. It checks that:
+//   - nothing was written in the program segment past the bytecode
+//   - every cell in a builtin segment is valid for that builtin
+//   - the range check segment has no holes
+//   - every address stored in memory points to an existing segment, so it can be relocated
+func (runner *ZeroRunner) VerifySecureRun() error {
+	memory := runner.vm.Memory
+
+	programSegment := memory.Segments[runner.programSegment]
+	if programSegment.Len() > uint64(len(runner.program.Bytecode)) {
+		return fmt.Errorf(
+			"program segment: write past the bytecode at offset %d",
+			programSegment.Len()-1,
+		)
+	}
+
+	for i, segment := range memory.Segments {
+		if segment.BuiltinRunner.String() != builtins.RangeCheckName {
+			continue
+		}
+		for offset := uint64(0); offset < segment.Len(); offset++ {
+			if !segment.Data[offset].Known() {
+				return fmt.Errorf(
+					"segment %d, offset %d: missing %s builtin cell",
+					i, offset, builtins.RangeCheckName,
+				)
+			}
+		}
+	}
+
+	var err error
+	memory.Iterate(func(segmentIndex int, offset uint64, value mem.MemoryValue) bool {
+		segment := memory.Segments[segmentIndex]
+		if err = segment.BuiltinRunner.CheckWrite(segment, offset, &value); err != nil {
+			err = fmt.Errorf(
+				"segment %d, offset %d: %s builtin: %w",
+				segmentIndex, offset, segment.BuiltinRunner, err,
+			)
+			return false
+		}
+
+		if address, addrErr := value.MemoryAddress(); addrErr == nil &&
+			address.SegmentIndex >= uint64(len(memory.Segments)) {
+			err = fmt.Errorf(
+				"segment %d, offset %d: address %s points to an unallocated segment",
+				segmentIndex, offset, address,
+			)
+			return false
+		}
+		return true
+	})
+	return err
+}
+
+// In proof mode the execution starts after the dummy fp and pc values
+const proofModeInitialFp = 2
+
+// Loads the program and writes the initial stack of its main entrypoint, returning the
+// address the run ends at. When the runner was handed the vm of a previous run, the
+// program is loaded in a new segment of it and the stack written past the previous one
 func (runner *ZeroRunner) InitializeMainEntrypoint() (mem.MemoryAddress, error) {
 	memory := mem.InitializeEmptyMemory()
-	_, err := memory.AllocateSegment(runner.program.Bytecode) // ProgramSegment
+	if runner.vm != nil {
+		memory = runner.vm.Memory
+	}
+	programSegment, err := memory.AllocateSegment(runner.program.Bytecode)
 	if err != nil {
 		return mem.UnknownAddress, err
 	}
+	runner.programSegment = uint64(programSegment)
 
-	memory.AllocateEmptySegment() // ExecutionSegment
+	if programSegment == vm.ProgramSegment {
+		memory.AllocateEmptySegment() // ExecutionSegment
+	}
 	if runner.proofmode {
 		initialPCOffset, ok := runner.program.Labels["__start__"]
 		if !ok {
@@ -88,32 +373,38 @@ func (runner *ZeroRunner) InitializeMainEntrypoint() (mem.MemoryAddress, error)
 		stack := runner.initializeBuiltins(memory)
 		// Add the dummy last fp and pc to the public memory, so that the verifier can enforce [fp - 2] = fp.
 		stack = append([]mem.MemoryValue{mem.MemoryValueFromSegmentAndOffset(
-			vm.ProgramSegment,
-			len(runner.program.Bytecode)+2,
+			runner.programSegment,
+			uint64(len(runner.program.Bytecode)+2),
 		), mem.EmptyMemoryValueAsFelt()}, stack...)
 
+		runner.initialFp = memory.Segments[vm.ExecutionSegment].Len() + proofModeInitialFp
 		if err := runner.initializeVm(&mem.MemoryAddress{
-			SegmentIndex: vm.ProgramSegment,
+			SegmentIndex: runner.programSegment,
 			Offset:       initialPCOffset,
 		}, stack, memory); err != nil {
 			return mem.UnknownAddress, err
 		}
 
 		// __start__ will advance Ap and Fp
-		runner.vm.Context.Ap = 2
-		runner.vm.Context.Fp = 2
-		return mem.MemoryAddress{SegmentIndex: vm.ProgramSegment, Offset: endPcOffset}, nil
+		runner.vm.Context.Ap = runner.initialFp
+		runner.vm.Context.Fp = runner.initialFp
+		return mem.MemoryAddress{SegmentIndex: runner.programSegment, Offset: endPcOffset}, nil
 	}
 
 	returnFp := mem.MemoryValueFromSegmentAndOffset(
 		memory.AllocateEmptySegment(),
 		0,
 	)
-	return runner.InitializeEntrypoint("main", nil, &returnFp, memory)
+
+	entrypoint := runner.options.Entrypoint
+	if entrypoint == "" {
+		entrypoint = "main"
+	}
+	return runner.InitializeEntrypoint(entrypoint, runner.options.Arguments, &returnFp, memory)
 }
 
 func (runner *ZeroRunner) InitializeEntrypoint(
-	funcName string, arguments []*f.Element, returnFp *mem.MemoryValue, memory *mem.Memory,
+	funcName string, arguments []mem.MemoryValue, returnFp *mem.MemoryValue, memory *mem.Memory,
 ) (mem.MemoryAddress, error) {
 	initialPCOffset, ok := runner.program.Entrypoints[funcName]
 	if !ok {
@@ -121,9 +412,7 @@ func (runner *ZeroRunner) InitializeEntrypoint(
 	}
 
 	stack := runner.initializeBuiltins(memory)
-	for i := range arguments {
-		stack = append(stack, mem.MemoryValueFromFieldElement(arguments[i]))
-	}
+	stack = append(stack, arguments...)
 	end := mem.MemoryAddress{
 		SegmentIndex: uint64(memory.AllocateEmptySegment()),
 		Offset:       0,
@@ -131,7 +420,7 @@ func (runner *ZeroRunner) InitializeEntrypoint(
 
 	stack = append(stack, *returnFp, mem.MemoryValueFromMemoryAddress(&end))
 	return end, runner.initializeVm(&mem.MemoryAddress{
-		SegmentIndex: vm.ProgramSegment,
+		SegmentIndex: runner.programSegment,
 		Offset:       initialPCOffset,
 	}, stack, memory)
 }
@@ -140,29 +429,78 @@ func (runner *ZeroRunner) initializeBuiltins(memory *mem.Memory) []mem.MemoryVal
 	stack := []mem.MemoryValue{}
 	for _, builtin := range runner.program.Builtins {
 		bRunner := builtins.Runner(builtin)
-		builtinSegment := memory.AllocateBuiltinSegment(bRunner)
-		stack = append(stack, mem.MemoryValueFromSegmentAndOffset(builtinSegment, 0))
+		if builtin == sn.ECDSA && runner.ecdsa != nil {
+			bRunner = runner.ecdsa
+		}
+		// a memory shared with previous runs already holds the builtin segment, and
+		// the run carries on from its last used cell
+		builtinSegment, ok := findBuiltinSegment(memory, bRunner.String())
+		if !ok {
+			builtinSegment = uint64(memory.AllocateBuiltinSegment(bRunner))
+		}
+		offset := memory.Segments[builtinSegment].Len()
+		if builtin == sn.Output {
+			runner.outputStart = offset
+		}
+		stack = append(stack, mem.MemoryValueFromSegmentAndOffset(builtinSegment, offset))
 	}
 	return stack
 }
 
+// Returns the index of the segment of the given builtin, if it was allocated
+func findBuiltinSegment(memory *mem.Memory, builtinName string) (uint64, bool) {
+	for i, segment := range memory.Segments {
+		if segment.BuiltinRunner.String() == builtinName {
+			return uint64(i), true
+		}
+	}
+	return 0, false
+}
+
 func (runner *ZeroRunner) initializeVm(
 	initialPC *mem.MemoryAddress, stack []mem.MemoryValue, memory *mem.Memory,
 ) error {
 	executionSegment := memory.Segments[vm.ExecutionSegment]
 	offset := executionSegment.Len()
+	fp := offset + uint64(len(stack))
+	if !runner.proofmode && runner.options.InitialFp != nil {
+		fp = *runner.options.InitialFp
+		if fp < uint64(len(stack)) {
+			return fmt.Errorf(
+				"initial fp %d leaves no room for a stack of %d values", fp, len(stack),
+			)
+		}
+		offset = fp - uint64(len(stack))
+	}
+	ap := fp
+	if !runner.proofmode && runner.options.InitialAp != nil {
+		ap = *runner.options.InitialAp
+		if ap < fp {
+			return fmt.Errorf("initial ap %d cannot be smaller than the initial fp %d", ap, fp)
+		}
+	}
+
 	for idx := range stack {
 		if err := executionSegment.Write(offset+uint64(idx), &stack[idx]); err != nil {
 			return err
 		}
 	}
 
+	runner.hintrunner.SetAvailableGas(runner.options.AvailableGas)
+
+	if runner.vm != nil {
+		// the vm is shared with previous runs, which keep their steps and trace
+		runner.initialStep = runner.vm.Step
+		runner.vm.Context = vm.Context{Pc: *initialPC, Ap: ap, Fp: fp}
+		return nil
+	}
+
 	var err error
 	// initialize vm
 	runner.vm, err = vm.NewVirtualMachine(vm.Context{
 		Pc: *initialPC,
-		Ap: offset + uint64(len(stack)),
-		Fp: offset + uint64(len(stack)),
+		Ap: ap,
+		Fp: fp,
 	}, memory, vm.VirtualMachineConfig{ProofMode: runner.proofmode})
 	return err
 }
@@ -170,13 +508,8 @@ func (runner *ZeroRunner) initializeVm(
 // run until the program counter equals the `pc` parameter
 func (runner *ZeroRunner) RunUntilPc(pc *mem.MemoryAddress) error {
 	for !runner.vm.Context.Pc.Equal(pc) {
-		if runner.steps() >= runner.maxsteps {
-			return fmt.Errorf(
-				"pc %s step %d: max step limit exceeded (%d)",
-				runner.pc(),
-				runner.steps(),
-				runner.maxsteps,
-			)
+		if err := runner.checkStepLimit(); err != nil {
+			return err
 		}
 		if err := runner.vm.RunStep(runner.hintrunner); err != nil {
 			return fmt.Errorf("pc %s step %d: %w", runner.pc(), runner.steps(), err)
@@ -185,16 +518,11 @@ func (runner *ZeroRunner) RunUntilPc(pc *mem.MemoryAddress) error {
 	return nil
 }
 
-// run until the vm step count reaches the `steps` parameter
+// run until the step count of the runner reaches the `steps` parameter
 func (runner *ZeroRunner) RunFor(steps uint64) error {
 	for runner.steps() < steps {
-		if runner.steps() >= runner.maxsteps {
-			return fmt.Errorf(
-				"pc %s step %d: max step limit exceeded (%d)",
-				runner.pc(),
-				runner.steps(),
-				runner.maxsteps,
-			)
+		if err := runner.checkStepLimit(); err != nil {
+			return err
 		}
 		if err := runner.vm.RunStep(runner.hintrunner); err != nil {
 			return fmt.Errorf(
@@ -208,42 +536,191 @@ func (runner *ZeroRunner) RunFor(steps uint64) error {
 	return nil
 }
 
+// Errors if the runner already took as many steps as its limit allows. A limit of 0
+// means the execution is unbounded
+func (runner *ZeroRunner) checkStepLimit() error {
+	if runner.maxsteps != 0 && runner.steps() >= runner.maxsteps {
+		return fmt.Errorf(
+			"pc %s step %d: max step limit exceeded (%d)",
+			runner.pc(),
+			runner.steps(),
+			runner.maxsteps,
+		)
+	}
+	return nil
+}
+
+// Drops the empty temporary segments once the run is over, so they don't take space in
+// the relocated memory, keeping the registers, the trace and the dictionaries tracked
+// by hints pointing to the same segments
+func (runner *ZeroRunner) CompactSegments() error {
+	if runner.vm == nil {
+		return errors.New("cannot compact the segments of an uninitialized runner")
+	}
+	newIndices := runner.vm.CompactSegments()
+	runner.hintrunner.RemapSegments(newIndices)
+	return nil
+}
+
+// Snapshot of an ongoing run, each part serialized by the component owning it
+type runnerState struct {
+	Vm    []byte
+	Hints []byte
+	Ecdsa []byte
+}
+
+// Serializes the state of an ongoing run: the vm registers and memory, the state shared
+// by hints and the signatures known by the ecdsa builtin, so that the run can be resumed
+// later by calling UnmarshalState
+func (runner *ZeroRunner) MarshalState() ([]byte, error) {
+	if runner.vm == nil {
+		return nil, errors.New("cannot serialize the state of an uninitialized runner")
+	}
+
+	state := runnerState{Vm: runner.vm.MarshalState()}
+	var err error
+	if state.Hints, err = runner.hintrunner.MarshalState(); err != nil {
+		return nil, err
+	}
+	if ecdsa, ok := runner.ecdsaRunner(); ok {
+		if state.Ecdsa, err = ecdsa.MarshalState(); err != nil {
+			return nil, err
+		}
+	}
+
+	var content bytes.Buffer
+	if err := gob.NewEncoder(&content).Encode(&state); err != nil {
+		return nil, fmt.Errorf("encode runner state: %w", err)
+	}
+	return content.Bytes(), nil
+}
+
+// Restores the state of a run serialized by MarshalState. The runner must already be
+// initialized from the same program, for instance with InitializeMainEntrypoint, so
+// its builtin runners exist and can be restored
+func (runner *ZeroRunner) UnmarshalState(content []byte) error {
+	if runner.vm == nil {
+		return errors.New("cannot restore the state of an uninitialized runner")
+	}
+
+	state := runnerState{}
+	if err := gob.NewDecoder(bytes.NewReader(content)).Decode(&state); err != nil {
+		return fmt.Errorf("decode runner state: %w", err)
+	}
+	if err := runner.vm.UnmarshalState(state.Vm); err != nil {
+		return err
+	}
+	if err := runner.hintrunner.UnmarshalState(state.Hints); err != nil {
+		return err
+	}
+	if ecdsa, ok := runner.ecdsaRunner(); ok && state.Ecdsa != nil {
+		if err := ecdsa.UnmarshalState(state.Ecdsa); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the ecdsa builtin runner of the program, if it uses it
+func (runner *ZeroRunner) ecdsaRunner() (*builtins.Ecdsa, bool) {
+	segment, ok := runner.vm.Memory.FindSegmentWithBuiltin(builtins.EcdsaName)
+	if !ok {
+		return nil, false
+	}
+	ecdsa, ok := segment.BuiltinRunner.(*builtins.Ecdsa)
+	return ecdsa, ok
+}
+
 func (runner *ZeroRunner) BuildProof() ([]byte, []byte, error) {
-	relocatedTrace, err := runner.vm.ExecutionTrace()
+	relocatedTrace, err := runner.vm.RelocateTrace()
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return vm.EncodeTrace(relocatedTrace), vm.EncodeMemory(runner.vm.RelocateMemory()), nil
+	relocatedMemory, err := runner.vm.RelocateMemory()
+	if err != nil {
+		return nil, nil, err
+	}
+	return vm.EncodeTrace(relocatedTrace), vm.EncodeMemory(relocatedMemory), nil
 }
 
 func (runner *ZeroRunner) pc() mem.MemoryAddress {
 	return runner.vm.Context.Pc
 }
 
+// Steps taken by this run, leaving out those of the previous runs sharing the vm
 func (runner *ZeroRunner) steps() uint64 {
-	return runner.vm.Step
+	return runner.vm.Step - runner.initialStep
+}
+
+// Returns the length of the trace handed to the prover. Once a proof mode run finishes
+// it is a power of two, since the step looping at `__end__` is repeated to pad it.
+// Outside of proof mode no trace is recorded, so it is 0
+func (runner *ZeroRunner) TraceLength() (uint64, error) {
+	if runner.vm == nil {
+		return 0, errors.New("cannot get the trace length from an uninitialized runner")
+	}
+	return uint64(len(runner.vm.Trace)), nil
+}
+
+// Reads the n values returned by main, which lay right before ap once the run is over.
+// Return values are expected to be field elements
+func (runner *ZeroRunner) ReturnValues(n uint64) ([]*fp.Element, error) {
+	if runner.vm == nil {
+		return nil, errors.New("cannot get the return values from an uninitialized runner")
+	}
+	if n > runner.vm.Context.Ap {
+		return nil, fmt.Errorf("ap %d leaves no room for %d return values", runner.vm.Context.Ap, n)
+	}
+
+	values := make([]*fp.Element, n)
+	start := runner.vm.Context.Ap - n
+	for i := range values {
+		value, err := runner.vm.Memory.Read(vm.ExecutionSegment, start+uint64(i))
+		if err != nil {
+			return nil, fmt.Errorf("return value %d: %w", i, err)
+		}
+		felt, err := value.FieldElement()
+		if err != nil {
+			return nil, fmt.Errorf("return value %d: %w", i, err)
+		}
+		values[i] = felt
+	}
+	return values, nil
 }
 
-// Gives the output of the last run. Panics if there hasn't
-// been any runs yet.
+// Compares the values returned by main against the expected ones. On mismatch, the
+// error lists every return value that differs
+func (runner *ZeroRunner) AssertReturnValues(expected []fp.Element) error {
+	values, err := runner.ReturnValues(uint64(len(expected)))
+	if err != nil {
+		return err
+	}
+
+	diff := ""
+	for i := range expected {
+		if !values[i].Equal(&expected[i]) {
+			diff += fmt.Sprintf("\n  return value %d: expected %s, got %s", i, &expected[i], values[i])
+		}
+	}
+	if diff != "" {
+		return fmt.Errorf("return values mismatch:%s", diff)
+	}
+	return nil
+}
+
+// Gives the output of the last run, as read by VirtualMachine.GetOutput. Panics if
+// there hasn't been any runs yet.
 func (runner *ZeroRunner) Output() []*fp.Element {
 	if runner.vm == nil {
 		panic("cannot get the output from an uninitialized runner")
 	}
 
+	// previous runs sharing the vm wrote their output before
+	vmOutput := runner.vm.GetOutput()
 	output := []*fp.Element{}
-	outputSegment, ok := runner.vm.Memory.FindSegmentWithBuiltin("output")
-	if !ok {
-		return output
-	}
-
-	for offset := uint64(0); offset < outputSegment.Len(); offset++ {
-		value := outputSegment.Peek(offset)
-		// no need to check for an error here since only felts can be written
-		// to the output segment
-		valueFelt, _ := value.FieldElement()
-		output = append(output, valueFelt)
+	for i := runner.outputStart; i < uint64(len(vmOutput)); i++ {
+		output = append(output, &vmOutput[i])
 	}
 	return output
 }
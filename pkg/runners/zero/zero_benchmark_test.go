@@ -219,7 +219,7 @@ func BenchmarkRunnerWithFibonacci(b *testing.B) {
 			panic(err)
 		}
 
-		runner, err := NewRunner(program, true, math.MaxUint64)
+		runner, err := NewRunner(program, true, false, math.MaxUint64)
 		if err != nil {
 			panic(err)
 		}
@@ -25,7 +25,7 @@ func TestSimpleProgram(t *testing.T) {
         ret;
     `)
 
-	runner, err := NewRunner(program, false, math.MaxUint64)
+	runner, err := NewRunner(program, false, false, math.MaxUint64)
 	require.NoError(t, err)
 
 	endPc, err := runner.InitializeMainEntrypoint()
@@ -71,7 +71,7 @@ func TestStepLimitExceeded(t *testing.T) {
         ret;
     `)
 
-	runner, err := NewRunner(program, false, 3)
+	runner, err := NewRunner(program, false, false, 3)
 	require.NoError(t, err)
 
 	endPc, err := runner.InitializeMainEntrypoint()
@@ -109,6 +109,39 @@ func TestStepLimitExceeded(t *testing.T) {
 	assert.Equal(t, uint64(3), runner.steps())
 }
 
+func TestStepLimitUnbounded(t *testing.T) {
+	code := `
+        [ap] = 2, ap++;
+        [ap] = [ap - 1] + 1, ap++;
+        ret;
+    `
+
+	// a zero limit doesn't bound the execution
+	runner, err := NewRunner(createProgram(code), false, false, 0)
+	require.NoError(t, err)
+	require.NoError(t, runner.Run())
+	require.Equal(t, uint64(3), runner.steps())
+
+	// a limit equal to the steps taken is not exceeded
+	runner, err = NewRunner(createProgram(code), false, false, 3)
+	require.NoError(t, err)
+	require.NoError(t, runner.Run())
+}
+
+func TestStepLimitLoop(t *testing.T) {
+	program := createProgram(`
+        [ap] = 1, ap++;
+        jmp rel 0;
+    `)
+
+	runner, err := NewRunner(program, false, false, 100)
+	require.NoError(t, err)
+
+	err = runner.Run()
+	require.ErrorContains(t, err, "step 100: max step limit exceeded (100)")
+	require.Equal(t, uint64(100), runner.steps())
+}
+
 func TestStepLimitExceededProofMode(t *testing.T) {
 	program := createProgram(`
         [ap] = 2;
@@ -129,7 +162,7 @@ func TestStepLimitExceededProofMode(t *testing.T) {
 		t.Logf("Using maxstep: %d\n", maxstep)
 		// when maxstep = 6, it fails executing the extra step required by proof mode
 		// when maxstep = 7, it fails trying to get the trace to be a power of 2
-		runner, err := NewRunner(program, true, uint64(maxstep))
+		runner, err := NewRunner(program, true, false, uint64(maxstep))
 		require.NoError(t, err)
 
 		err = runner.Run()
@@ -165,6 +198,320 @@ func TestStepLimitExceededProofMode(t *testing.T) {
 	}
 }
 
+func TestProofModePadding(t *testing.T) {
+	program := createProgramWithBuiltins(`
+        ap += 1;
+        call rel 4;
+        jmp rel 0;
+        [ap] = [fp - 3], ap++;
+        ret;
+    `, sn.Output)
+	// __start__ calls main which is located right after __end__
+	program.Labels = map[string]uint64{
+		"__start__": 0,
+		"__end__":   4,
+	}
+
+	runner, err := NewRunner(program, true, false, math.MaxUint64)
+	require.NoError(t, err)
+	require.NoError(t, runner.Run())
+
+	// 4 steps reach __end__, one more is required by proof mode and the result is
+	// padded to the next power of two by repeating the step looping at __end__
+	require.Equal(t, uint64(8), runner.steps())
+	traceLength, err := runner.TraceLength()
+	require.NoError(t, err)
+	require.Equal(t, uint64(8), traceLength)
+
+	start := memory.MemoryAddress{SegmentIndex: vm.ProgramSegment, Offset: program.Labels["__start__"]}
+	end := memory.MemoryAddress{SegmentIndex: vm.ProgramSegment, Offset: program.Labels["__end__"]}
+	require.Equal(t, start, runner.vm.Trace[0].Pc)
+	for _, context := range runner.vm.Trace[4:] {
+		require.Equal(t, end, context.Pc)
+	}
+	require.Equal(t, end, runner.vm.Context.Pc)
+
+	// no trace is recorded outside of proof mode
+	runner, err = NewRunner(createProgram("ret;"), false, false, math.MaxUint64)
+	require.NoError(t, err)
+	require.NoError(t, runner.Run())
+	traceLength, err = runner.TraceLength()
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), traceLength)
+
+	runner, err = NewRunner(createProgram("ret;"), true, false, math.MaxUint64)
+	require.NoError(t, err)
+	_, err = runner.TraceLength()
+	require.ErrorContains(t, err, "cannot get the trace length from an uninitialized runner")
+}
+
+func TestProofModeTraceLength(t *testing.T) {
+	for writes := 0; writes < 16; writes++ {
+		// main pushes some locals before returning the output pointer
+		main := ""
+		for i := 1; i <= writes; i++ {
+			main += fmt.Sprintf("[ap] = %d, ap++;\n", i)
+		}
+		program := createProgramWithBuiltins(`
+            ap += 1;
+            call rel 4;
+            jmp rel 0;
+        `+main+`
+            [ap] = [fp - 3], ap++;
+            ret;
+        `, sn.Output)
+		program.Labels = map[string]uint64{
+			"__start__": 0,
+			"__end__":   4,
+		}
+
+		runner, err := NewRunner(program, true, false, math.MaxUint64)
+		require.NoError(t, err)
+		require.NoError(t, runner.Run())
+
+		traceLength, err := runner.TraceLength()
+		require.NoError(t, err)
+		// __start__ takes 2 steps and main writes + 2 more to return to __end__,
+		// which runs at least once more
+		wrapperSteps := uint64(writes + 5)
+		require.GreaterOrEqual(t, traceLength, wrapperSteps, "%d writes", writes)
+		require.Less(t, traceLength, 2*wrapperSteps, "%d writes", writes)
+		require.Zero(t, traceLength&(traceLength-1), "trace length %d is not a power of two", traceLength)
+
+		start := memory.MemoryAddress{SegmentIndex: vm.ProgramSegment, Offset: program.Labels["__start__"]}
+		end := memory.MemoryAddress{SegmentIndex: vm.ProgramSegment, Offset: program.Labels["__end__"]}
+		require.Equal(t, start, runner.vm.Trace[0].Pc)
+		for _, context := range runner.vm.Trace[wrapperSteps-1:] {
+			require.Equal(t, end, context.Pc)
+		}
+	}
+}
+
+func TestProofModeFinalStack(t *testing.T) {
+	testCases := []struct {
+		name        string
+		main        string
+		expectedErr string
+	}{
+		{
+			name: "main returns the output pointer",
+			main: `
+                [ap] = [fp - 3], ap++;
+                ret;
+            `,
+		},
+		{
+			name: "main returns nothing",
+			main: `
+                ret;
+            `,
+			expectedErr: "final stack: final ap 5: expected a pointer to the output builtin at offset 4",
+		},
+		{
+			name: "main uses locals before returning the output pointer",
+			main: `
+                [ap] = 1, ap++;
+                [ap] = 2, ap++;
+                [ap] = [fp - 3], ap++;
+                ret;
+            `,
+		},
+		{
+			name: "main returns a value instead of the output pointer",
+			main: `
+                [ap] = 1, ap++;
+                ret;
+            `,
+			expectedErr: "final stack: final ap 6: expected a pointer to the output builtin at offset 5",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			program := createProgramWithBuiltins(`
+                ap += 1;
+                call rel 4;
+                jmp rel 0;
+            `+tc.main, sn.Output)
+			// __start__ calls main which is located right after __end__
+			program.Labels = map[string]uint64{
+				"__start__": 0,
+				"__end__":   4,
+			}
+
+			runner, err := NewRunner(program, true, false, math.MaxUint64)
+			require.NoError(t, err)
+
+			err = runner.Run()
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestRunMany(t *testing.T) {
+	results, trace, relocatedMemory, err := RunMany(
+		[]*Program{createOutputProgram(7), createOutputProgram(8, 9)},
+		true,
+		false,
+		math.MaxUint64,
+	)
+	require.NoError(t, err)
+
+	require.Len(t, results, 2)
+	require.Equal(t, []*fp.Element{new(fp.Element).SetUint64(7)}, results[0].Output)
+	require.Equal(
+		t,
+		[]*fp.Element{new(fp.Element).SetUint64(8), new(fp.Element).SetUint64(9)},
+		results[1].Output,
+	)
+
+	// the programs aren't padded on their own, only the combined trace is padded up
+	// to the next power of two
+	require.Equal(t, uint64(6), results[0].Steps)
+	require.Equal(t, uint64(8), results[1].Steps)
+	require.Len(t, trace, 16)
+	require.NotEmpty(t, relocatedMemory)
+}
+
+func TestRunManyRelocatedAddressesDontCollide(t *testing.T) {
+	results, trace, relocatedMemory, err := RunMany(
+		[]*Program{createOutputProgram(7), createOutputProgram(8, 9)},
+		true,
+		false,
+		math.MaxUint64,
+	)
+	require.NoError(t, err)
+	first := trace[:results[0].Steps]
+	second := trace[results[0].Steps : results[0].Steps+results[1].Steps]
+
+	// every program runs from its own segment
+	firstPcs := make(map[uint64]bool)
+	for _, entry := range first {
+		firstPcs[entry.Pc] = true
+	}
+	for _, entry := range second {
+		require.False(t, firstPcs[entry.Pc], "pc %d is used by both programs", entry.Pc)
+	}
+
+	// and its stack lays past the one of the previous program
+	var firstStackEnd uint64
+	for _, entry := range first {
+		firstStackEnd = max(firstStackEnd, entry.Ap, entry.Fp)
+	}
+	for _, entry := range second {
+		require.Greater(t, entry.Fp, firstStackEnd)
+		require.Greater(t, entry.Ap, firstStackEnd)
+	}
+
+	// both programs write to the same output segment, one after the other
+	output := []*fp.Element{
+		new(fp.Element).SetUint64(7), new(fp.Element).SetUint64(8), new(fp.Element).SetUint64(9),
+	}
+	found := false
+	for i := 0; i+len(output) <= len(relocatedMemory) && !found; i++ {
+		found = true
+		for j := range output {
+			value := relocatedMemory[i+j]
+			found = found && value != nil && value.Equal(output[j])
+		}
+	}
+	require.True(t, found, "the output of both programs isn't contiguous")
+}
+
+func TestRunManyFailingProgram(t *testing.T) {
+	failing := createProgram("[ap] = 5; [ap] = 6;")
+
+	_, _, _, err := RunMany(
+		[]*Program{createProgram("ret;"), failing}, false, false, math.MaxUint64,
+	)
+	require.ErrorContains(t, err, "program 1:")
+}
+
+func TestAssertReturnValues(t *testing.T) {
+	runner := createRunner(`
+        [ap] = 42, ap++;
+        ret;
+    `)
+
+	err := runner.Run()
+	require.NoError(t, err)
+
+	err = runner.AssertReturnValues([]fp.Element{fp.NewElement(42)})
+	require.NoError(t, err)
+
+	err = runner.AssertReturnValues([]fp.Element{fp.NewElement(43)})
+	require.EqualError(t, err, "return values mismatch:\n  return value 0: expected 43, got 42")
+}
+
+func TestAssertReturnValuesNotFelt(t *testing.T) {
+	runner := createRunner(`
+        [ap] = 42, ap++;
+        ret;
+    `)
+
+	err := runner.Run()
+	require.NoError(t, err)
+
+	// right before the returned value lays the return pc of main
+	err = runner.AssertReturnValues([]fp.Element{fp.NewElement(0), fp.NewElement(42)})
+	require.ErrorContains(t, err, "return value 0: memory value is not a field element")
+}
+
+func TestRunEntrypointWithArguments(t *testing.T) {
+	program := createProgram(`
+        ret;
+        [ap] = [fp - 4] + [fp - 3], ap++;
+        ret;
+    `)
+	program.Entrypoints["add"] = 1
+
+	runner, err := NewRunner(program, false, false, math.MaxUint64)
+	require.NoError(t, err)
+
+	initialFp := uint64(10)
+	initialAp := uint64(12)
+	runner.SetRunOptions(RunOptions{
+		Entrypoint: "add",
+		Arguments:  []memory.MemoryValue{memory.MemoryValueFromInt(7), memory.MemoryValueFromInt(5)},
+		InitialFp:  &initialFp,
+		InitialAp:  &initialAp,
+	})
+
+	err = runner.Run()
+	require.NoError(t, err)
+
+	// the arguments, return fp and return pc are written right before fp
+	executionSegment := runner.vm.Memory.Segments[vm.ExecutionSegment]
+	require.Equal(t, memory.MemoryValueFromInt(7), executionSegment.Peek(6))
+	require.Equal(t, memory.MemoryValueFromInt(5), executionSegment.Peek(7))
+	require.Equal(t, memory.MemoryValueFromInt(12), executionSegment.Peek(12))
+	require.NoError(t, runner.AssertReturnValues([]fp.Element{fp.NewElement(12)}))
+}
+
+func TestRunOptionsInitialFpTooSmall(t *testing.T) {
+	runner := createRunner("ret;")
+
+	initialFp := uint64(1)
+	runner.SetRunOptions(RunOptions{InitialFp: &initialFp})
+
+	err := runner.Run()
+	require.ErrorContains(t, err, "initial fp 1 leaves no room for a stack of 2 values")
+}
+
+func TestRunOptionsAvailableGas(t *testing.T) {
+	runner := createRunner("ret;")
+	runner.SetRunOptions(RunOptions{AvailableGas: 1000})
+
+	err := runner.Run()
+	require.NoError(t, err)
+	// no hint consumed any of it
+	require.Equal(t, uint64(1000), runner.hintrunner.AvailableGas())
+}
+
 func TestBitwiseBuiltin(t *testing.T) {
 	// bitwise segment ptr is located at fp - 3 (fp - 2 and fp - 1 contain initialization vals)
 	// We first write 16 and 8 to bitwise. Then we read the bitwise result from &, ^ and |
@@ -240,6 +587,42 @@ func TestOutputBuiltin(t *testing.T) {
 	require.Equal(t, []*fp.Element{&val1, &val2}, output)
 }
 
+func TestGetOutput(t *testing.T) {
+	// output builtin is located at fp - 3
+	runner := createRunner(`
+        [ap] = 11;
+        [ap] = [[fp - 3]];
+        [ap + 1] = 3;
+        [ap + 1] = [[fp - 3] + 1];
+        [ap + 2] = 0;
+        [ap + 2] = [[fp - 3] + 2];
+        [ap + 3] = 42;
+        [ap + 3] = [[fp - 3] + 3];
+        ret;
+    `, sn.Output)
+	err := runner.Run()
+	require.NoError(t, err)
+
+	expected := []fp.Element{fp.NewElement(11), fp.NewElement(3), fp.NewElement(0), fp.NewElement(42)}
+	require.Equal(t, expected, runner.vm.GetOutput())
+
+	// cells the program never wrote are read as zero by the runner too
+	runner = createRunner(`
+        [ap] = 5;
+        [ap] = [[fp - 3] + 1];
+        ret;
+    `, sn.Output)
+	require.NoError(t, runner.Run())
+	require.Equal(t, []fp.Element{fp.NewElement(0), fp.NewElement(5)}, runner.vm.GetOutput())
+	zero, five := fp.NewElement(0), fp.NewElement(5)
+	require.Equal(t, []*fp.Element{&zero, &five}, runner.Output())
+
+	// a program without the output builtin has no output
+	runner = createRunner("ret;")
+	require.NoError(t, runner.Run())
+	require.Empty(t, runner.vm.GetOutput())
+}
+
 func TestPedersenBuiltin(t *testing.T) {
 	val1 := fp.NewElement(5)
 	val2 := fp.NewElement(7)
@@ -284,6 +667,98 @@ func TestPedersenBuiltinError(t *testing.T) {
 	require.ErrorContains(t, err, "input value at offset 0 is unknown")
 }
 
+func TestEcdsaBuiltin(t *testing.T) {
+	publicKey, message, r, s := ecdsaTestVector()
+
+	// ecdsa builtin is located at fp - 3
+	code := fmt.Sprintf(`
+        [ap] = %s;
+        [ap] = [[fp - 3]];
+        [ap + 1] = %s;
+        [ap + 1] = [[fp - 3] + 1];
+        ret;
+    `, publicKey.Text(10), message.Text(10))
+
+	testCases := []struct {
+		name        string
+		s           *fp.Element
+		expectedErr string
+	}{
+		{"valid signature", s, ""},
+		{"tampered signature", new(fp.Element).Add(s, new(fp.Element).SetOne()), "invalid signature"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			runner := createRunner(code, sn.ECDSA)
+			require.NoError(t, runner.AddEcdsaSignature(0, r, tc.s))
+
+			err := runner.Run()
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+
+	runner := createRunner(code, sn.ECDSA)
+	err := runner.Run()
+	require.ErrorContains(t, err, "signature missing for the public key at offset 0")
+
+	err = runner.AddEcdsaSignature(0, r, s)
+	require.ErrorContains(t, err, "cannot add a signature once the run started")
+}
+
+func TestResumeRunFromState(t *testing.T) {
+	publicKey, message, r, s := ecdsaTestVector()
+
+	// ecdsa builtin is located at fp - 3
+	code := fmt.Sprintf(`
+        [ap] = %s;
+        [ap] = [[fp - 3]];
+        [ap + 1] = %s;
+        [ap + 1] = [[fp - 3] + 1];
+        ret;
+    `, publicKey.Text(10), message.Text(10))
+
+	uninterrupted := createRunner(code, sn.ECDSA)
+	require.NoError(t, uninterrupted.AddEcdsaSignature(0, r, s))
+	require.NoError(t, uninterrupted.Run())
+
+	interrupted := createRunner(code, sn.ECDSA)
+	require.NoError(t, interrupted.AddEcdsaSignature(0, r, s))
+	_, err := interrupted.InitializeMainEntrypoint()
+	require.NoError(t, err)
+	require.NoError(t, interrupted.RunFor(2))
+	state, err := interrupted.MarshalState()
+	require.NoError(t, err)
+
+	// the signature is only known through the restored state
+	resumed := createRunner(code, sn.ECDSA)
+	end, err := resumed.InitializeMainEntrypoint()
+	require.NoError(t, err)
+	require.NoError(t, resumed.UnmarshalState(state))
+	require.Equal(t, uint64(2), resumed.steps())
+	require.NoError(t, resumed.RunUntilPc(&end))
+	require.NoError(t, resumed.checkEcdsaSignatures())
+
+	require.Equal(t, uninterrupted.vm.Context, resumed.vm.Context)
+	require.Equal(t, uninterrupted.steps(), resumed.steps())
+	for i := range uninterrupted.vm.Memory.Segments {
+		require.Equal(
+			t,
+			trimmedSegment(uninterrupted.vm.Memory.Segments[i]),
+			trimmedSegment(resumed.vm.Memory.Segments[i]),
+			"segment %d", i,
+		)
+	}
+
+	uninitialized := createRunner(code, sn.ECDSA)
+	_, err = uninitialized.MarshalState()
+	require.ErrorContains(t, err, "cannot serialize the state of an uninitialized runner")
+}
+
 func TestRangeCheckBuiltin(t *testing.T) {
 	// range check is located at fp - 3 (fp - 2 and fp - 1 contain initialization vals)
 	// we write 5 and 2**128 - 1 to range check
@@ -331,10 +806,46 @@ func TestRangeCheckBuiltinError(t *testing.T) {
 
 }
 
+func TestSecureRun(t *testing.T) {
+	program := createProgramWithBuiltins(`
+        [ap] = 5, ap++;
+        [ap - 1] = [[fp - 3]];
+        ret;
+    `, sn.RangeCheck)
+
+	runner, err := NewRunner(program, false, true, math.MaxUint64)
+	require.NoError(t, err)
+
+	err = runner.Run()
+	require.NoError(t, err)
+}
+
+func TestSecureRunProgramSegmentWrite(t *testing.T) {
+	// the called function gets a pointer to the program segment through
+	// its return pc and uses it to write past the bytecode
+	code := `
+        call rel 3;
+        ret;
+        [ap] = 5, ap++;
+        [ap - 1] = [[fp - 1] + 10];
+        ret;
+    `
+
+	runner, err := NewRunner(createProgram(code), false, false, math.MaxUint64)
+	require.NoError(t, err)
+	err = runner.Run()
+	require.NoError(t, err)
+
+	runner, err = NewRunner(createProgram(code), false, true, math.MaxUint64)
+	require.NoError(t, err)
+	err = runner.Run()
+	require.ErrorContains(t, err, "secure run: program segment: write past the bytecode")
+}
+
 func createRunner(code string, builtins ...sn.Builtin) ZeroRunner {
 	program := createProgramWithBuiltins(code, builtins...)
 
-	runner, err := NewRunner(program, false, math.MaxUint64)
+	runner, err := NewRunner(program, false, false, math.MaxUint64)
 	if err != nil {
 		panic(err)
 	}
@@ -401,3 +912,97 @@ func createProgramWithBuiltins(code string, builtins ...sn.Builtin) *Program {
 	program.Builtins = builtins
 	return program
 }
+
+// StarkEx crypto test vector: the x coordinate of the public key of the private key
+// 0x3c1e9550e66958296d11b60f8e8e7a7ad990d07fa65d5f7652c4a6c87d4e3cc, a message hash and
+// its (r, s) signature
+func ecdsaTestVector() (*fp.Element, *fp.Element, *fp.Element, *fp.Element) {
+	felt := func(hex string) *fp.Element {
+		value, err := new(fp.Element).SetString(hex)
+		if err != nil {
+			panic(err)
+		}
+		return value
+	}
+	return felt("0x77a3b314db07c45076d11f62b6f9e748a39790441823307743cf00d6597ea43"),
+		felt("0x397e76d1667c4454bfb83514e120583af836f8e32a516765497823eabe16a3f"),
+		felt("0x173fd03d8b008ee7432977ac27d1e9d1a1f6c98b1a2f05fa84a21c84c44e882"),
+		felt("0x4b6d75385aed025aa222f28a0adc6d58db78ff17e51c3f59e259b131cd5a1cc")
+}
+
+// creates a proof mode program whose main writes the given values to the output
+func createOutputProgram(values ...int) *Program {
+	code := `
+        ap += 1;
+        call rel 4;
+        jmp rel 0;
+    `
+	for i, value := range values {
+		code += fmt.Sprintf("[ap] = %d, ap++;\n[ap - 1] = [[fp - 3] + %d];\n", value, i)
+	}
+	code += fmt.Sprintf("[ap] = [fp - 3] + %d, ap++;\nret;\n", len(values))
+
+	program := createProgramWithBuiltins(code, sn.Output)
+	program.Labels = map[string]uint64{
+		"__start__": 0,
+		"__end__":   4,
+	}
+	return program
+}
+
+func TestVerifyBuiltinPointers(t *testing.T) {
+	testCases := []struct {
+		name        string
+		main        string
+		expectedErr string
+	}{
+		{
+			name: "pointer advanced past the used cells",
+			main: `
+                [ap] = 3, ap++;
+                [ap - 1] = [[fp - 3]];
+                [ap] = 4, ap++;
+                [ap - 1] = [[fp - 3] + 1];
+                [ap] = [fp - 3] + 2, ap++;
+                ret;
+            `,
+		},
+		{
+			name: "pointer went backward",
+			main: `
+                [ap] = 3, ap++;
+                [ap - 1] = [[fp - 3]];
+                [ap] = 4, ap++;
+                [ap - 1] = [[fp - 3] + 1];
+                [ap] = [fp - 3] + 1, ap++;
+                ret;
+            `,
+			expectedErr: "builtin pointers: output builtin pointer went backward: final offset 1 but 2 cells are used",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			program := createProgramWithBuiltins(`
+                ap += 1;
+                call rel 4;
+                jmp rel 0;
+            `+tc.main, sn.Output)
+			// __start__ calls main which is located right after __end__
+			program.Labels = map[string]uint64{
+				"__start__": 0,
+				"__end__":   4,
+			}
+
+			runner, err := NewRunner(program, true, false, math.MaxUint64)
+			require.NoError(t, err)
+
+			err = runner.Run()
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
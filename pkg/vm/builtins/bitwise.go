@@ -3,6 +3,7 @@ package builtins
 import (
 	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
 	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
@@ -13,6 +14,9 @@ const BitwiseName = "bitwise"
 const cellsPerBitwise = 5
 const inputCellsPerBitwise = 2
 
+// Inputs must fit in this many bits
+const bitwiseTotalBits = 251
+
 type Bitwise struct{}
 
 func (b *Bitwise) CheckWrite(
@@ -51,37 +55,33 @@ func (b *Bitwise) InferValue(segment *memory.Segment, offset uint64) error {
 		return err
 	}
 
-	xBytes := xFelt.Bytes()
-	yBytes := yFelt.Bytes()
-
-	var bitwiseValue memory.MemoryValue
-	var bitwiseFelt fp.Element
-	var bitwiseBytes [32]byte
-	for i := 0; i < 32; i++ {
-		bitwiseBytes[i] = xBytes[i] & yBytes[i]
-	}
-	bitwiseFelt.SetBytes(bitwiseBytes[:])
-	bitwiseValue = memory.MemoryValueFromFieldElement(&bitwiseFelt)
-	if err := segment.Write(xOffset+2, &bitwiseValue); err != nil {
-		return err
-	}
-
-	for i := 0; i < 32; i++ {
-		bitwiseBytes[i] = xBytes[i] ^ yBytes[i]
+	x := xFelt.BigInt(new(big.Int))
+	if x.BitLen() > bitwiseTotalBits {
+		return fmt.Errorf(
+			"cannot infer value: input value at offset %d should be smaller than 2**%d",
+			xOffset, bitwiseTotalBits,
+		)
 	}
-	bitwiseFelt.SetBytes(bitwiseBytes[:])
-	bitwiseValue = memory.MemoryValueFromFieldElement(&bitwiseFelt)
-	if err := segment.Write(xOffset+3, &bitwiseValue); err != nil {
-		return err
+	y := yFelt.BigInt(new(big.Int))
+	if y.BitLen() > bitwiseTotalBits {
+		return fmt.Errorf(
+			"cannot infer value: input value at offset %d should be smaller than 2**%d",
+			yOffset, bitwiseTotalBits,
+		)
 	}
 
-	for i := 0; i < 32; i++ {
-		bitwiseBytes[i] = xBytes[i] | yBytes[i]
+	results := []*big.Int{
+		new(big.Int).And(x, y),
+		new(big.Int).Xor(x, y),
+		new(big.Int).Or(x, y),
 	}
-	bitwiseFelt.SetBytes(bitwiseBytes[:])
-	bitwiseValue = memory.MemoryValueFromFieldElement(&bitwiseFelt)
-	if err := segment.Write(xOffset+4, &bitwiseValue); err != nil {
-		return err
+	for i, result := range results {
+		var bitwiseFelt fp.Element
+		bitwiseFelt.SetBigInt(result)
+		bitwiseValue := memory.MemoryValueFromFieldElement(&bitwiseFelt)
+		if err := segment.Write(xOffset+inputCellsPerBitwise+uint64(i), &bitwiseValue); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -39,3 +39,62 @@ func TestBitwise(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", xOrYFelt.Text(16))
 }
+
+func TestBitwiseValues(t *testing.T) {
+	testCases := []struct {
+		name         string
+		x, y         string
+		and, xor, or string
+	}{
+		{"small values", "0xc", "0xa", "8", "6", "e"},
+		{"zero", "0x0", "0x5", "0", "5", "5"},
+		{
+			"near the bit bound",
+			"0x7ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+			"0x400000000000000000000000000000000000000000000000000000000000001",
+			"400000000000000000000000000000000000000000000000000000000000001",
+			"3fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffe",
+			"7ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			segment := memory.EmptySegmentWithLength(5)
+			segment.WithBuiltinRunner(&Bitwise{})
+
+			x, err := new(fp.Element).SetString(tc.x)
+			require.NoError(t, err)
+			y, err := new(fp.Element).SetString(tc.y)
+			require.NoError(t, err)
+			xValue := memory.MemoryValueFromFieldElement(x)
+			yValue := memory.MemoryValueFromFieldElement(y)
+			require.NoError(t, segment.Write(0, &xValue))
+			require.NoError(t, segment.Write(1, &yValue))
+
+			for i, expected := range []string{tc.and, tc.xor, tc.or} {
+				value, err := segment.Read(uint64(2 + i))
+				require.NoError(t, err)
+				felt, err := value.FieldElement()
+				require.NoError(t, err)
+				assert.Equal(t, expected, felt.Text(16), "cell %d", 2+i)
+			}
+		})
+	}
+}
+
+func TestBitwiseOutOfBound(t *testing.T) {
+	segment := memory.EmptySegmentWithLength(5)
+	segment.WithBuiltinRunner(&Bitwise{})
+
+	// 2**251
+	x, err := new(fp.Element).SetString("0x800000000000000000000000000000000000000000000000000000000000000")
+	require.NoError(t, err)
+	xValue := memory.MemoryValueFromFieldElement(x)
+	yValue := memory.MemoryValueFromFieldElement(new(fp.Element).SetUint64(1))
+	require.NoError(t, segment.Write(0, &xValue))
+	require.NoError(t, segment.Write(1, &yValue))
+
+	_, err = segment.Read(3)
+	assert.ErrorContains(t, err, "input value at offset 0 should be smaller than 2**251")
+}
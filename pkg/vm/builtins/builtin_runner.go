@@ -14,15 +14,15 @@ func Runner(name starknetParser.Builtin) memory.BuiltinRunner {
 	case starknetParser.Pedersen:
 		return &Pedersen{}
 	case starknetParser.ECDSA:
-		panic("Not implemented")
+		return &Ecdsa{}
 	case starknetParser.Keccak:
-		panic("Not implemented")
+		return &Keccak{}
 	case starknetParser.Bitwise:
 		return &Bitwise{}
 	case starknetParser.ECOP:
-		panic("Not implemented")
+		return &EcOp{}
 	case starknetParser.Poseidon:
-		panic("Not implemented")
+		return &Poseidon{}
 	case starknetParser.SegmentArena:
 		panic("Not implemented")
 	default:
@@ -0,0 +1,252 @@
+package builtins
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
+	starkcurve "github.com/consensys/gnark-crypto/ecc/stark-curve"
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fr"
+)
+
+const EcdsaName = "ecdsa"
+const cellsPerEcdsa = 2
+
+// Signature of the message of an ecdsa instance
+type EcdsaSignature struct {
+	R fp.Element
+	S fp.Element
+}
+
+// Verifies stark curve signatures. Each instance is laid out as the x coordinate of the
+// public key followed by the message hash, and its signature is registered keyed by the
+// offset of the public key cell. An instance is verified as soon as both of its cells
+// are known and its signature is registered, whichever happens last
+type Ecdsa struct {
+	signatures map[uint64]EcdsaSignature
+	// instances whose cells are known but whose signature is not registered yet,
+	// indexed by the offset of their public key cell
+	unsigned map[uint64]ecdsaInstance
+}
+
+type ecdsaInstance struct {
+	publicKey fp.Element
+	message   fp.Element
+}
+
+// Registers the signature of the instance whose public key is at pubKeyOffset. If both
+// cells of the instance are already known the signature is verified right away
+func (e *Ecdsa) AddSignature(pubKeyOffset uint64, r, s *fp.Element) error {
+	if pubKeyOffset%cellsPerEcdsa != 0 {
+		return fmt.Errorf("offset %d is not the public key cell of an instance", pubKeyOffset)
+	}
+	if e.signatures == nil {
+		e.signatures = make(map[uint64]EcdsaSignature)
+	}
+	signature := EcdsaSignature{R: *r, S: *s}
+	e.signatures[pubKeyOffset] = signature
+
+	instance, ok := e.unsigned[pubKeyOffset]
+	if !ok {
+		return nil
+	}
+	delete(e.unsigned, pubKeyOffset)
+	return verifyInstance(pubKeyOffset, &instance.publicKey, &instance.message, &signature)
+}
+
+// Errors if any instance whose cells are known never had its signature registered
+func (e *Ecdsa) CheckSignatures() error {
+	missing := false
+	lowestOffset := uint64(0)
+	for offset := range e.unsigned {
+		if !missing || offset < lowestOffset {
+			lowestOffset = offset
+		}
+		missing = true
+	}
+	if missing {
+		return fmt.Errorf("signature missing for the public key at offset %d", lowestOffset)
+	}
+	return nil
+}
+
+// Snapshot of the ecdsa builtin state with every field exported, as gob requires
+type ecdsaState struct {
+	Signatures map[uint64]EcdsaSignature
+	Unsigned   map[uint64]ecdsaInstanceState
+}
+
+type ecdsaInstanceState struct {
+	PublicKey fp.Element
+	Message   fp.Element
+}
+
+// Serializes the registered signatures and the instances still waiting for theirs, so
+// that a run can be resumed later by calling UnmarshalState
+func (e *Ecdsa) MarshalState() ([]byte, error) {
+	state := ecdsaState{
+		Signatures: e.signatures,
+		Unsigned:   make(map[uint64]ecdsaInstanceState, len(e.unsigned)),
+	}
+	for offset, instance := range e.unsigned {
+		state.Unsigned[offset] = ecdsaInstanceState{
+			PublicKey: instance.publicKey,
+			Message:   instance.message,
+		}
+	}
+
+	var content bytes.Buffer
+	if err := gob.NewEncoder(&content).Encode(&state); err != nil {
+		return nil, fmt.Errorf("encode ecdsa state: %w", err)
+	}
+	return content.Bytes(), nil
+}
+
+// Replaces the state of the builtin with one serialized by MarshalState
+func (e *Ecdsa) UnmarshalState(content []byte) error {
+	state := ecdsaState{}
+	if err := gob.NewDecoder(bytes.NewReader(content)).Decode(&state); err != nil {
+		return fmt.Errorf("decode ecdsa state: %w", err)
+	}
+
+	e.signatures = state.Signatures
+	e.unsigned = nil
+	for offset, instance := range state.Unsigned {
+		if e.unsigned == nil {
+			e.unsigned = make(map[uint64]ecdsaInstance, len(state.Unsigned))
+		}
+		e.unsigned[offset] = ecdsaInstance{
+			publicKey: instance.PublicKey,
+			message:   instance.Message,
+		}
+	}
+	return nil
+}
+
+func (e *Ecdsa) CheckWrite(segment *memory.Segment, offset uint64, value *memory.MemoryValue) error {
+	if !value.IsFelt() {
+		return fmt.Errorf("expected a felt but got an address: %s", value)
+	}
+
+	pubKeyOffset := offset - offset%cellsPerEcdsa
+	cells := [cellsPerEcdsa]*memory.MemoryValue{}
+	for i := range cells {
+		cell := segment.Peek(pubKeyOffset + uint64(i))
+		cells[i] = &cell
+	}
+	cells[offset-pubKeyOffset] = value
+	// the instance is verified once the other cell is written
+	if !cells[0].Known() || !cells[1].Known() {
+		return nil
+	}
+
+	publicKey, err := cells[0].FieldElement()
+	if err != nil {
+		return err
+	}
+	message, err := cells[1].FieldElement()
+	if err != nil {
+		return err
+	}
+	signature, ok := e.signatures[pubKeyOffset]
+	if !ok {
+		// verified once the signature is registered
+		if e.unsigned == nil {
+			e.unsigned = make(map[uint64]ecdsaInstance)
+		}
+		e.unsigned[pubKeyOffset] = ecdsaInstance{publicKey: *publicKey, message: *message}
+		return nil
+	}
+	return verifyInstance(pubKeyOffset, publicKey, message, &signature)
+}
+
+func verifyInstance(pubKeyOffset uint64, publicKey, message *fp.Element, signature *EcdsaSignature) error {
+	valid, err := VerifyEcdsa(publicKey, message, &signature.R, &signature.S)
+	if err != nil {
+		return fmt.Errorf("signature at offset %d: %w", pubKeyOffset, err)
+	}
+	if !valid {
+		return fmt.Errorf(
+			"invalid signature (%s, %s) for message %s and public key %s",
+			&signature.R, &signature.S, message, publicKey,
+		)
+	}
+	return nil
+}
+
+func (e *Ecdsa) InferValue(segment *memory.Segment, offset uint64) error {
+	return errors.New("cannot infer value")
+}
+
+func (e *Ecdsa) String() string {
+	return EcdsaName
+}
+
+// 2 ** 251
+var ecdsaBound = new(big.Int).Lsh(big.NewInt(1), 251)
+
+// Verifies a stark curve ECDSA signature following Starkware's reference implementation.
+// Only the x coordinate of the public key is known, so both possible y coordinates are tried
+func VerifyEcdsa(publicKeyX, message, r, s *fp.Element) (bool, error) {
+	order := fr.Modulus()
+
+	rBig := r.BigInt(new(big.Int))
+	sBig := s.BigInt(new(big.Int))
+	messageBig := message.BigInt(new(big.Int))
+	if sBig.Sign() == 0 || sBig.Cmp(order) >= 0 {
+		return false, fmt.Errorf("s %s is out of range", s)
+	}
+	if rBig.Sign() == 0 || rBig.Cmp(ecdsaBound) >= 0 {
+		return false, fmt.Errorf("r %s is out of range", r)
+	}
+	if messageBig.Cmp(ecdsaBound) >= 0 {
+		return false, fmt.Errorf("message %s is out of range", message)
+	}
+	w := new(big.Int).ModInverse(sBig, order)
+	if w.Cmp(ecdsaBound) >= 0 {
+		return false, fmt.Errorf("w %s is out of range", w)
+	}
+
+	publicKey, ok := PointFromX(publicKeyX)
+	if !ok {
+		return false, fmt.Errorf("public key %s is not on the curve", publicKeyX)
+	}
+
+	_, generator := starkcurve.Generators()
+	zG := new(starkcurve.G1Jac).ScalarMultiplicationAffine(&generator, messageBig)
+	for i := 0; i < 2; i++ {
+		rQ := new(starkcurve.G1Jac).ScalarMultiplicationAffine(&publicKey, rBig)
+		rQ.AddAssign(zG)
+		rQ.ScalarMultiplication(rQ, w)
+
+		result := starkcurve.G1Affine{}
+		result.FromJacobian(rQ)
+		if result.X.Equal(r) {
+			return true, nil
+		}
+		publicKey.Neg(&publicKey)
+	}
+	return false, nil
+}
+
+// Given the x coordinate of a point returns one of the points of the curve
+// with such coordinate, and false if there is none
+func PointFromX(x *fp.Element) (starkcurve.G1Affine, bool) {
+	a, b := starkcurve.CurveCoefficients()
+
+	// y^2 = x^3 + a * x + b
+	ySquared := new(fp.Element).Square(x)
+	ySquared.Mul(ySquared, x)
+	ySquared.Add(ySquared, new(fp.Element).Mul(&a, x))
+	ySquared.Add(ySquared, &b)
+
+	y := new(fp.Element).Sqrt(ySquared)
+	if y == nil {
+		return starkcurve.G1Affine{}, false
+	}
+	return starkcurve.G1Affine{X: *x, Y: *y}, true
+}
@@ -0,0 +1,147 @@
+package builtins
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// StarkEx crypto test vector: the x coordinate of the public key of the private key
+// 0x3c1e9550e66958296d11b60f8e8e7a7ad990d07fa65d5f7652c4a6c87d4e3cc, a message hash and
+// its (r, s) signature
+func ecdsaTestVector() (fp.Element, fp.Element, fp.Element, fp.Element) {
+	felt := func(hex string) fp.Element {
+		value, err := new(fp.Element).SetString(hex)
+		if err != nil {
+			panic(err)
+		}
+		return *value
+	}
+	return felt("0x77a3b314db07c45076d11f62b6f9e748a39790441823307743cf00d6597ea43"),
+		felt("0x397e76d1667c4454bfb83514e120583af836f8e32a516765497823eabe16a3f"),
+		felt("0x173fd03d8b008ee7432977ac27d1e9d1a1f6c98b1a2f05fa84a21c84c44e882"),
+		felt("0x4b6d75385aed025aa222f28a0adc6d58db78ff17e51c3f59e259b131cd5a1cc")
+}
+
+func TestEcdsa(t *testing.T) {
+	publicKey, message, r, s := ecdsaTestVector()
+	tamperedS := new(fp.Element).Add(&s, new(fp.Element).SetOne())
+
+	testCases := []struct {
+		name        string
+		s           *fp.Element
+		expectedErr string
+	}{
+		{"valid signature", &s, ""},
+		{"tampered signature", tamperedS, "invalid signature"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ecdsa := &Ecdsa{}
+			// the signature belongs to the second instance
+			require.NoError(t, ecdsa.AddSignature(2, &r, tc.s))
+			segment := memory.EmptySegmentWithLength(2 * cellsPerEcdsa)
+			segment.WithBuiltinRunner(ecdsa)
+
+			publicKeyValue := memory.MemoryValueFromFieldElement(&publicKey)
+			require.NoError(t, segment.Write(2, &publicKeyValue))
+
+			messageValue := memory.MemoryValueFromFieldElement(&message)
+			err := segment.Write(3, &messageValue)
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr)
+				stored := segment.Peek(3)
+				assert.False(t, stored.Known())
+			}
+		})
+	}
+}
+
+func TestEcdsaSignatureRegisteredLast(t *testing.T) {
+	publicKey, message, r, s := ecdsaTestVector()
+	tamperedS := new(fp.Element).Add(&s, new(fp.Element).SetOne())
+
+	testCases := []struct {
+		name        string
+		s           *fp.Element
+		expectedErr string
+	}{
+		{"valid signature", &s, ""},
+		{"tampered signature", tamperedS, "invalid signature"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ecdsa := &Ecdsa{}
+			segment := memory.EmptySegmentWithLength(cellsPerEcdsa)
+			segment.WithBuiltinRunner(ecdsa)
+
+			// the message is written before the public key
+			messageValue := memory.MemoryValueFromFieldElement(&message)
+			require.NoError(t, segment.Write(1, &messageValue))
+			publicKeyValue := memory.MemoryValueFromFieldElement(&publicKey)
+			require.NoError(t, segment.Write(0, &publicKeyValue))
+			require.ErrorContains(t, ecdsa.CheckSignatures(), "signature missing for the public key at offset 0")
+
+			err := ecdsa.AddSignature(0, &r, tc.s)
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr)
+			}
+			require.NoError(t, ecdsa.CheckSignatures())
+		})
+	}
+}
+
+func TestEcdsaMarshalUnmarshalState(t *testing.T) {
+	publicKey, message, r, s := ecdsaTestVector()
+
+	ecdsa := &Ecdsa{}
+	require.NoError(t, ecdsa.AddSignature(2, &r, &s))
+	segment := memory.EmptySegmentWithLength(2 * cellsPerEcdsa)
+	segment.WithBuiltinRunner(ecdsa)
+
+	// the first instance waits for its signature
+	publicKeyValue := memory.MemoryValueFromFieldElement(&publicKey)
+	messageValue := memory.MemoryValueFromFieldElement(&message)
+	require.NoError(t, segment.Write(0, &publicKeyValue))
+	require.NoError(t, segment.Write(1, &messageValue))
+
+	state, err := ecdsa.MarshalState()
+	require.NoError(t, err)
+	restored := &Ecdsa{}
+	require.NoError(t, restored.UnmarshalState(state))
+	require.Equal(t, ecdsa, restored)
+
+	require.ErrorContains(t, restored.CheckSignatures(), "signature missing for the public key at offset 0")
+	require.NoError(t, restored.AddSignature(0, &r, &s))
+	require.NoError(t, restored.CheckSignatures())
+}
+
+func TestEcdsaErrors(t *testing.T) {
+	ecdsa := &Ecdsa{}
+	one := fp.NewElement(1)
+	require.ErrorContains(t, ecdsa.AddSignature(1, &one, &one), "offset 1 is not the public key cell of an instance")
+
+	segment := memory.EmptySegmentWithLength(2 * cellsPerEcdsa)
+	segment.WithBuiltinRunner(ecdsa)
+
+	address := memory.MemoryValueFromSegmentAndOffset(0, 0)
+	require.ErrorContains(t, segment.Write(1, &address), "expected a felt but got an address")
+
+	// an instance is not verified until its signature is registered
+	value := memory.MemoryValueFromInt(5)
+	require.NoError(t, segment.Write(1, &value))
+	require.NoError(t, segment.Write(0, &value))
+	require.ErrorContains(t, ecdsa.CheckSignatures(), "signature missing for the public key at offset 0")
+
+	_, err := segment.Read(2)
+	require.ErrorContains(t, err, "cannot infer value")
+}
@@ -0,0 +1,70 @@
+package builtins
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
+	starkcurve "github.com/consensys/gnark-crypto/ecc/stark-curve"
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+)
+
+const EcOpName = "ec_op"
+const cellsPerEcOp = 7
+const inputCellsPerEcOp = 5
+
+// Computes R = P + m * Q over the stark curve. Each instance is laid out as
+// P.x, P.y, Q.x, Q.y, m, R.x, R.y
+type EcOp struct{}
+
+func (e *EcOp) CheckWrite(segment *memory.Segment, offset uint64, value *memory.MemoryValue) error {
+	return nil
+}
+
+func (e *EcOp) InferValue(segment *memory.Segment, offset uint64) error {
+	ecOpIndex := offset % cellsPerEcOp
+	// input cell
+	if ecOpIndex < inputCellsPerEcOp {
+		return errors.New("cannot infer value")
+	}
+
+	inputOffset := offset - ecOpIndex
+	inputs := [inputCellsPerEcOp]*fp.Element{}
+	for i := range inputs {
+		value := segment.Peek(inputOffset + uint64(i))
+		if !value.Known() {
+			return fmt.Errorf("cannot infer value: input value at offset %d is unknown", inputOffset+uint64(i))
+		}
+		felt, err := value.FieldElement()
+		if err != nil {
+			return err
+		}
+		inputs[i] = felt
+	}
+
+	p := starkcurve.G1Affine{X: *inputs[0], Y: *inputs[1]}
+	q := starkcurve.G1Affine{X: *inputs[2], Y: *inputs[3]}
+	for _, point := range []*starkcurve.G1Affine{&p, &q} {
+		if !point.IsOnCurve() {
+			return fmt.Errorf("cannot infer value: point (%s, %s) is not on the curve", &point.X, &point.Y)
+		}
+	}
+
+	r := new(starkcurve.G1Affine).ScalarMultiplication(&q, inputs[4].BigInt(new(big.Int)))
+	r.Add(&p, r)
+	if r.IsInfinity() {
+		return errors.New("cannot infer value: the result is the point at infinity")
+	}
+
+	rX := memory.MemoryValueFromFieldElement(&r.X)
+	if err := segment.Write(inputOffset+inputCellsPerEcOp, &rX); err != nil {
+		return err
+	}
+	rY := memory.MemoryValueFromFieldElement(&r.Y)
+	return segment.Write(inputOffset+inputCellsPerEcOp+1, &rY)
+}
+
+func (e *EcOp) String() string {
+	return EcOpName
+}
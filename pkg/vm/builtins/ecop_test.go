@@ -0,0 +1,115 @@
+package builtins
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
+	starkcurve "github.com/consensys/gnark-crypto/ecc/stark-curve"
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeEcOpInputs(t *testing.T, segment *memory.Segment, inputs ...*fp.Element) {
+	for i, input := range inputs {
+		value := memory.MemoryValueFromFieldElement(input)
+		require.NoError(t, segment.Write(uint64(i), &value))
+	}
+}
+
+func TestEcOp(t *testing.T) {
+	segment := memory.EmptySegmentWithLength(cellsPerEcOp)
+	segment.WithBuiltinRunner(&EcOp{})
+
+	felt := func(value string) *fp.Element {
+		felt, err := new(fp.Element).SetString(value)
+		require.NoError(t, err)
+		return felt
+	}
+	writeEcOpInputs(
+		t,
+		segment,
+		felt("0x6a4beaef5a93425b973179cdba0c9d42f30e01a5f1e2db73da0884b8d6756fc"),
+		felt("0x72565ec81bc09ff53fbfad99324a92aa5b39fb58267e395e8abe36290ebf24f"),
+		felt("0x654fd7e67a123dd13868093b3b7777f1ffef596c2e324f25ceaf9146698482c"),
+		felt("0x4fad269cbf860980e38768fe9cb6b0b9ab03ee3fe84cfde2eccce597c874fd8"),
+		felt("0x22"),
+	)
+
+	rX, err := segment.Read(5)
+	require.NoError(t, err)
+	rXFelt, err := rX.FieldElement()
+	require.NoError(t, err)
+	assert.Equal(t, "3da64ebd8ba9f51fe5f91314a5cf9e8aa426189d2b28407622e99f1a9ef220", rXFelt.Text(16))
+
+	rY, err := segment.Read(6)
+	require.NoError(t, err)
+	rYFelt, err := rY.FieldElement()
+	require.NoError(t, err)
+	assert.Equal(t, "3853f456249dba2cef3645c4530934cbfe4812619fb5c0b2700a7b11b417d7b", rYFelt.Text(16))
+}
+
+func TestEcOpGenerator(t *testing.T) {
+	_, generator := starkcurve.Generators()
+
+	// G + m * G = (m + 1) * G
+	for _, m := range []int64{0, 1, 2, 41} {
+		segment := memory.EmptySegmentWithLength(cellsPerEcOp)
+		segment.WithBuiltinRunner(&EcOp{})
+		writeEcOpInputs(
+			t,
+			segment,
+			&generator.X, &generator.Y, &generator.X, &generator.Y, new(fp.Element).SetInt64(m),
+		)
+
+		expected := new(starkcurve.G1Affine).ScalarMultiplication(&generator, big.NewInt(m+1))
+
+		rX, err := segment.Read(5)
+		require.NoError(t, err)
+		rY, err := segment.Read(6)
+		require.NoError(t, err)
+		assert.Equal(t, memory.MemoryValueFromFieldElement(&expected.X), rX, "m = %d", m)
+		assert.Equal(t, memory.MemoryValueFromFieldElement(&expected.Y), rY, "m = %d", m)
+	}
+}
+
+func TestEcOpOffCurve(t *testing.T) {
+	_, generator := starkcurve.Generators()
+
+	segment := memory.EmptySegmentWithLength(cellsPerEcOp)
+	segment.WithBuiltinRunner(&EcOp{})
+	writeEcOpInputs(
+		t,
+		segment,
+		&generator.X, &generator.Y, new(fp.Element).SetOne(), new(fp.Element).SetOne(), new(fp.Element).SetOne(),
+	)
+
+	_, err := segment.Read(5)
+	assert.ErrorContains(t, err, "point (1, 1) is not on the curve")
+}
+
+func TestEcOpPointAtInfinity(t *testing.T) {
+	_, generator := starkcurve.Generators()
+
+	// G + (n - 1) * G is the point at infinity, n being the curve order
+	m := new(fp.Element).SetBigInt(new(big.Int).Sub(fr.Modulus(), big.NewInt(1)))
+	segment := memory.EmptySegmentWithLength(cellsPerEcOp)
+	segment.WithBuiltinRunner(&EcOp{})
+	writeEcOpInputs(t, segment, &generator.X, &generator.Y, &generator.X, &generator.Y, m)
+
+	_, err := segment.Read(5)
+	assert.ErrorContains(t, err, "the result is the point at infinity")
+}
+
+func TestEcOpInfer(t *testing.T) {
+	segment := memory.EmptySegmentWithLength(cellsPerEcOp)
+	segment.WithBuiltinRunner(&EcOp{})
+
+	_, err := segment.Read(4)
+	assert.ErrorContains(t, err, "cannot infer value")
+
+	_, err = segment.Read(6)
+	assert.ErrorContains(t, err, "input value at offset 0 is unknown")
+}
@@ -0,0 +1,147 @@
+package builtins
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	"github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+)
+
+const KeccakName = "keccak"
+const cellsPerKeccak = 16
+const inputCellsPerKeccak = 8
+
+// Every cell holds 200 bits of the 1600 bits state
+const keccakBitsPerCell = 200
+const keccakBytesPerCell = keccakBitsPerCell / 8
+
+// Applies the keccak-f[1600] permutation. The state is split in 8 cells of 200 bits,
+// each one taken as little-endian bytes, so the instance is laid out as the 8 cells of
+// the input state followed by the 8 cells of the permuted one
+type Keccak struct{}
+
+func (k *Keccak) CheckWrite(segment *memory.Segment, offset uint64, value *memory.MemoryValue) error {
+	return nil
+}
+
+func (k *Keccak) InferValue(segment *memory.Segment, offset uint64) error {
+	keccakIndex := offset % cellsPerKeccak
+	// input cell
+	if keccakIndex < inputCellsPerKeccak {
+		return errors.New("cannot infer value")
+	}
+
+	inputOffset := offset - keccakIndex
+	var stateBytes [inputCellsPerKeccak * keccakBytesPerCell]byte
+	for i := uint64(0); i < inputCellsPerKeccak; i++ {
+		value := segment.Peek(inputOffset + i)
+		if !value.Known() {
+			return fmt.Errorf("cannot infer value: input value at offset %d is unknown", inputOffset+i)
+		}
+		felt, err := value.FieldElement()
+		if err != nil {
+			return err
+		}
+
+		chunk := felt.BigInt(new(big.Int))
+		if chunk.BitLen() > keccakBitsPerCell {
+			return fmt.Errorf(
+				"cannot infer value: input value at offset %d should be smaller than 2**%d",
+				inputOffset+i, keccakBitsPerCell,
+			)
+		}
+		var chunkBytes [keccakBytesPerCell]byte
+		chunk.FillBytes(chunkBytes[:])
+		for j := range chunkBytes {
+			stateBytes[i*keccakBytesPerCell+uint64(j)] = chunkBytes[keccakBytesPerCell-1-j]
+		}
+	}
+
+	var state [25]uint64
+	for i := range state {
+		for j := 7; j >= 0; j-- {
+			state[i] = state[i]<<8 | uint64(stateBytes[8*i+j])
+		}
+	}
+	keccakF1600(&state)
+	for i := range state {
+		for j := 0; j < 8; j++ {
+			stateBytes[8*i+j] = byte(state[i] >> (8 * j))
+		}
+	}
+
+	for i := uint64(0); i < inputCellsPerKeccak; i++ {
+		var chunkBytes [keccakBytesPerCell]byte
+		for j := range chunkBytes {
+			chunkBytes[keccakBytesPerCell-1-j] = stateBytes[i*keccakBytesPerCell+uint64(j)]
+		}
+
+		var felt fp.Element
+		felt.SetBytes(chunkBytes[:])
+		value := memory.MemoryValueFromFieldElement(&felt)
+		if err := segment.Write(inputOffset+inputCellsPerKeccak+i, &value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (k *Keccak) String() string {
+	return KeccakName
+}
+
+var keccakRoundConstants = [24]uint64{
+	0x0000000000000001, 0x0000000000008082, 0x800000000000808a, 0x8000000080008000,
+	0x000000000000808b, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+	0x000000000000008a, 0x0000000000000088, 0x0000000080008009, 0x000000008000000a,
+	0x000000008000808b, 0x800000000000008b, 0x8000000000008089, 0x8000000000008003,
+	0x8000000000008002, 0x8000000000000080, 0x000000000000800a, 0x800000008000000a,
+	0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+}
+
+// Rotation offsets of the rho step, indexed as x + 5 * y
+var keccakRotations = [25]int{
+	0, 1, 62, 28, 27,
+	36, 44, 6, 55, 20,
+	3, 10, 43, 25, 39,
+	41, 45, 15, 21, 8,
+	18, 2, 61, 56, 14,
+}
+
+// Applies the keccak-f[1600] permutation in place, the lane (x, y) being state[x + 5 * y]
+func keccakF1600(state *[25]uint64) {
+	for round := 0; round < 24; round++ {
+		// theta
+		var c [5]uint64
+		for x := 0; x < 5; x++ {
+			c[x] = state[x] ^ state[x+5] ^ state[x+10] ^ state[x+15] ^ state[x+20]
+		}
+		for x := 0; x < 5; x++ {
+			d := c[(x+4)%5] ^ bits.RotateLeft64(c[(x+1)%5], 1)
+			for y := 0; y < 25; y += 5 {
+				state[x+y] ^= d
+			}
+		}
+
+		// rho and pi
+		var b [25]uint64
+		for x := 0; x < 5; x++ {
+			for y := 0; y < 5; y++ {
+				b[y+5*((2*x+3*y)%5)] = bits.RotateLeft64(state[x+5*y], keccakRotations[x+5*y])
+			}
+		}
+
+		// chi
+		for y := 0; y < 25; y += 5 {
+			for x := 0; x < 5; x++ {
+				state[x+y] = b[x+y] ^ (^b[(x+1)%5+y] & b[(x+2)%5+y])
+			}
+		}
+
+		// iota
+		state[0] ^= keccakRoundConstants[round]
+	}
+}
@@ -0,0 +1,92 @@
+package builtins
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeccakF1600ZeroState(t *testing.T) {
+	state := [25]uint64{}
+	keccakF1600(&state)
+
+	// first lanes of the permuted zero state, from the keccak team intermediate values
+	assert.Equal(t, uint64(0xf1258f7940e1dde7), state[0])
+	assert.Equal(t, uint64(0x84d5ccf933c0478a), state[1])
+	assert.Equal(t, uint64(0xd598261ea65aa9ee), state[2])
+	assert.Equal(t, uint64(0xbd1547306f80494d), state[3])
+}
+
+func TestKeccak(t *testing.T) {
+	mem := memory.InitializeEmptyMemory()
+	segmentIndex := uint64(mem.AllocateBuiltinSegment(&Keccak{}))
+
+	// the state absorbing an empty message for keccak256: a 0x01 byte at the start and
+	// a 0x80 byte at the end of the 136 bytes rate, which is byte 10 of cell 5
+	inputs := make([]*big.Int, inputCellsPerKeccak)
+	for i := range inputs {
+		inputs[i] = big.NewInt(0)
+	}
+	inputs[0] = big.NewInt(1)
+	inputs[5] = new(big.Int).Lsh(big.NewInt(0x80), 80)
+	for i, input := range inputs {
+		value := memory.MemoryValueFromFieldElement(new(fp.Element).SetBigInt(input))
+		require.NoError(t, mem.Write(segmentIndex, uint64(i), &value))
+	}
+
+	// the first 32 bytes of the permuted state are keccak256("")
+	first, err := mem.Read(segmentIndex, inputCellsPerKeccak)
+	require.NoError(t, err)
+	firstFelt, err := first.FieldElement()
+	require.NoError(t, err)
+	assert.Equal(t, "7b3b2782ca53b600e5c003c7dcb27d7e923c23f7860146d2c5", firstFelt.Text(16))
+
+	second, err := mem.Read(segmentIndex, inputCellsPerKeccak+1)
+	require.NoError(t, err)
+	secondFelt, err := second.FieldElement()
+	require.NoError(t, err)
+	secondLow := new(big.Int).And(secondFelt.BigInt(new(big.Int)), big.NewInt(1<<56-1))
+	assert.Equal(t, "70a4855d04d8fa", secondLow.Text(16))
+
+	// every output cell was deduced and fits in 200 bits
+	for i := uint64(inputCellsPerKeccak); i < cellsPerKeccak; i++ {
+		require.True(t, mem.KnownValue(segmentIndex, i))
+		value, err := mem.Read(segmentIndex, i)
+		require.NoError(t, err)
+		felt, err := value.FieldElement()
+		require.NoError(t, err)
+		assert.LessOrEqual(t, felt.BigInt(new(big.Int)).BitLen(), keccakBitsPerCell)
+	}
+}
+
+func TestKeccakInputOutOfBound(t *testing.T) {
+	segment := memory.EmptySegmentWithLength(cellsPerKeccak)
+	segment.WithBuiltinRunner(&Keccak{})
+
+	for i := uint64(0); i < inputCellsPerKeccak; i++ {
+		input := big.NewInt(0)
+		if i == 3 {
+			input.Lsh(big.NewInt(1), keccakBitsPerCell)
+		}
+		value := memory.MemoryValueFromFieldElement(new(fp.Element).SetBigInt(input))
+		require.NoError(t, segment.Write(i, &value))
+	}
+
+	_, err := segment.Read(cellsPerKeccak - 1)
+	assert.ErrorContains(t, err, "input value at offset 3 should be smaller than 2**200")
+}
+
+func TestKeccakInfer(t *testing.T) {
+	segment := memory.EmptySegmentWithLength(cellsPerKeccak)
+	segment.WithBuiltinRunner(&Keccak{})
+
+	_, err := segment.Read(7)
+	assert.ErrorContains(t, err, "cannot infer value")
+
+	_, err = segment.Read(8)
+	assert.ErrorContains(t, err, "input value at offset 0 is unknown")
+}
@@ -27,3 +27,66 @@ func TestPedersen(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "30e480bed5fe53fa909cc0f8c4d99b8f9f2c016be4c41e13a4848797979c662", pedersenXYFelt.Text(16))
 }
+
+func TestPedersenReferenceVectors(t *testing.T) {
+	testCases := []struct {
+		x, y     string
+		expected string
+	}{
+		{"0x0", "0x0", "49ee3eba8c1600700ee1b87eb599f16716b0b1022947733551fde4050ca6804"},
+		{"0x1", "0x2", "5bb9440e27889a364bcb678b1f679ecd1347acdedcbf36e83494f857cc58026"},
+		{
+			"0x03d937c035c878245caf64531a5756109c53068da139362728feb561405371cb",
+			"0x0208a0a10250e382e1e4bbe2880906c2791bf6275695e02fbbc6aeff9cd8b31a",
+			"30e480bed5fe53fa909cc0f8c4d99b8f9f2c016be4c41e13a4848797979c662",
+		},
+	}
+
+	// every pair is hashed by a different instance of the same segment, through
+	// the memory read path
+	mem := memory.InitializeEmptyMemory()
+	segmentIndex := uint64(mem.AllocateBuiltinSegment(&Pedersen{}))
+	for i, tc := range testCases {
+		instance := uint64(i) * cellsPerPedersen
+
+		x, err := new(fp.Element).SetString(tc.x)
+		require.NoError(t, err)
+		y, err := new(fp.Element).SetString(tc.y)
+		require.NoError(t, err)
+		xValue := memory.MemoryValueFromFieldElement(x)
+		yValue := memory.MemoryValueFromFieldElement(y)
+		require.NoError(t, mem.Write(segmentIndex, instance, &xValue))
+		require.NoError(t, mem.Write(segmentIndex, instance+1, &yValue))
+
+		// the output is unknown until it is read
+		require.False(t, mem.KnownValue(segmentIndex, instance+2))
+		hash, err := mem.Read(segmentIndex, instance+2)
+		require.NoError(t, err)
+		hashFelt, err := hash.FieldElement()
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, hashFelt.Text(16), "instance %d", i)
+		require.True(t, mem.KnownValue(segmentIndex, instance+2))
+	}
+}
+
+func TestPedersenInferErrors(t *testing.T) {
+	pedersen := &Pedersen{}
+	segment := memory.EmptySegmentWithLength(3)
+	segment.WithBuiltinRunner(pedersen)
+
+	_, err := segment.Read(0)
+	assert.ErrorContains(t, err, "cannot infer value")
+
+	_, err = segment.Read(2)
+	assert.ErrorContains(t, err, "input value at offset 0 is unknown")
+
+	xValue := memory.MemoryValueFromFieldElement(new(fp.Element).SetUint64(1))
+	require.NoError(t, segment.Write(0, &xValue))
+	_, err = segment.Read(2)
+	assert.ErrorContains(t, err, "input value at offset 1 is unknown")
+
+	address := memory.MemoryValueFromSegmentAndOffset(0, 0)
+	require.NoError(t, segment.Write(1, &address))
+	_, err = segment.Read(2)
+	assert.ErrorContains(t, err, "memory value is not a field element")
+}
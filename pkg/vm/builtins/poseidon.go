@@ -0,0 +1,112 @@
+package builtins
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+)
+
+const PoseidonName = "poseidon"
+const cellsPerPoseidon = 6
+const inputCellsPerPoseidon = 3
+
+const poseidonFullRounds = 8
+const poseidonPartialRounds = 83
+
+var poseidonRoundKeys = parsePoseidonRoundKeys()
+
+func parsePoseidonRoundKeys() [][3]fp.Element {
+	roundKeys := make([][3]fp.Element, len(poseidonRoundKeysSpec))
+	for i := range poseidonRoundKeysSpec {
+		for j := range poseidonRoundKeysSpec[i] {
+			if _, err := roundKeys[i][j].SetString(poseidonRoundKeysSpec[i][j]); err != nil {
+				panic(err)
+			}
+		}
+	}
+	return roundKeys
+}
+
+// Applies the Hades permutation of the StarkNet Poseidon hash in place: full rounds
+// at the beginning and at the end and partial rounds in between. Every round adds the
+// round keys, cubes the state (only its last element on partial rounds) and multiplies
+// it by the MDS matrix ((3, 1, 1), (1, -1, 1), (1, 1, -2))
+func PoseidonPermutation(state *[3]fp.Element) {
+	totalRounds := poseidonFullRounds + poseidonPartialRounds
+	for round := 0; round < totalRounds; round++ {
+		full := round < poseidonFullRounds/2 || totalRounds-round <= poseidonFullRounds/2
+
+		for i := range state {
+			state[i].Add(&state[i], &poseidonRoundKeys[round][i])
+		}
+
+		squared := fp.Element{}
+		for i := range state {
+			if full || i == len(state)-1 {
+				squared.Square(&state[i])
+				state[i].Mul(&state[i], &squared)
+			}
+		}
+
+		sum := fp.Element{}
+		sum.Add(&state[0], &state[1]).Add(&sum, &state[2])
+		state[0].Double(&state[0]).Add(&state[0], &sum)
+		state[1].Double(&state[1]).Sub(&sum, &state[1])
+		tripled := fp.Element{}
+		tripled.Double(&state[2]).Add(&tripled, &state[2])
+		state[2].Sub(&sum, &tripled)
+	}
+}
+
+// Computes the Poseidon hash of two field elements, as StarkNet does, by permuting
+// the state [x, y, 2] and taking its first element
+func PoseidonHash(x, y *fp.Element) fp.Element {
+	state := [3]fp.Element{*x, *y, fp.NewElement(2)}
+	PoseidonPermutation(&state)
+	return state[0]
+}
+
+// Applies the Poseidon permutation to a state of three elements. Each instance is laid
+// out as the three input elements followed by the three permuted ones
+type Poseidon struct{}
+
+func (p *Poseidon) CheckWrite(segment *memory.Segment, offset uint64, value *memory.MemoryValue) error {
+	return nil
+}
+
+func (p *Poseidon) InferValue(segment *memory.Segment, offset uint64) error {
+	poseidonIndex := offset % cellsPerPoseidon
+	// input cell
+	if poseidonIndex < inputCellsPerPoseidon {
+		return errors.New("cannot infer value")
+	}
+
+	inputOffset := offset - poseidonIndex
+	state := [inputCellsPerPoseidon]fp.Element{}
+	for i := range state {
+		value := segment.Peek(inputOffset + uint64(i))
+		if !value.Known() {
+			return fmt.Errorf("cannot infer value: input value at offset %d is unknown", inputOffset+uint64(i))
+		}
+		felt, err := value.FieldElement()
+		if err != nil {
+			return err
+		}
+		state[i] = *felt
+	}
+
+	PoseidonPermutation(&state)
+	for i := range state {
+		value := memory.MemoryValueFromFieldElement(&state[i])
+		if err := segment.Write(inputOffset+inputCellsPerPoseidon+uint64(i), &value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Poseidon) String() string {
+	return PoseidonName
+}
@@ -0,0 +1,101 @@
+package builtins
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoseidonHash(t *testing.T) {
+	x := fp.NewElement(1)
+	y := fp.NewElement(2)
+
+	expected, err := new(fp.Element).SetString("0x5d44a3decb2b2e0cc71071f7b802f45dd792d064f0fc7316c46514f70f9891a")
+	require.NoError(t, err)
+
+	hash := PoseidonHash(&x, &y)
+	require.Equal(t, *expected, hash)
+}
+
+func TestPoseidonPermutation(t *testing.T) {
+	// hashing an empty array pads it with a single 1 and permutes the state [1, 0, 0]
+	state := [3]fp.Element{fp.NewElement(1), fp.NewElement(0), fp.NewElement(0)}
+	PoseidonPermutation(&state)
+
+	expected, err := new(fp.Element).SetString("0x2272be0f580fd156823304800919530eaa97430e972d7213ee13f4fbf7a5dbc")
+	require.NoError(t, err)
+	require.Equal(t, *expected, state[0])
+}
+
+func TestPoseidonBuiltin(t *testing.T) {
+	// the permutation of [0, 0, 0] is the test vector from
+	// https://github.com/starkware-industries/poseidon, the other ones were computed
+	// with the permutation of Juno
+	testCases := []struct {
+		name     string
+		input    [3]uint64
+		expected [3]string
+	}{
+		{"zero state", [3]uint64{0, 0, 0}, [3]string{
+			"0x79e8d1e78258000a28fc9d49e233bc6852357968577b1e386550ed6a9086133",
+			"0x3840d003d0f3f96dbb796ff6aa6a63be5b5404b91ccaabca256154cbb6fb984",
+			"0x1eb39da3f7d3b04142d0ac83d9da00c9325a61fb2ef326e50b70eaa8a3c7cc7",
+		}},
+		{"empty array padding", [3]uint64{1, 0, 0}, [3]string{
+			"0x2272be0f580fd156823304800919530eaa97430e972d7213ee13f4fbf7a5dbc",
+			"0x1b86b27b134edc0dfca11c2a04d3458a70d97fdae254849160b41e387e25e4f",
+			"0x31ca7f335016cbb3d2328f45684564b601fdf38f841515338dd551484e222c2",
+		}},
+		{"hash of 1 and 2", [3]uint64{1, 2, 2}, [3]string{
+			"0x5d44a3decb2b2e0cc71071f7b802f45dd792d064f0fc7316c46514f70f9891a",
+			"0x7f2f8d1ef958b7831a9e9957c724459781575684490b7ae6eacd3aed1f25cf5",
+			"0x68163dd4c74a3fd7cdca0cdcb80ea7b4a55b3b9f18b0b57698fbd8e5c0623c8",
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// the instance is the second one of the segment
+			mem := memory.InitializeEmptyMemory()
+			segmentIndex := uint64(mem.AllocateBuiltinSegment(&Poseidon{}))
+			for i, input := range tc.input {
+				value := memory.MemoryValueFromFieldElement(new(fp.Element).SetUint64(input))
+				require.NoError(t, mem.Write(segmentIndex, cellsPerPoseidon+uint64(i), &value))
+			}
+
+			first, err := mem.Read(segmentIndex, cellsPerPoseidon+3)
+			require.NoError(t, err)
+			for i := range tc.expected {
+				expected, err := new(fp.Element).SetString(tc.expected[i])
+				require.NoError(t, err)
+				if i == 0 {
+					assert.Equal(t, memory.MemoryValueFromFieldElement(expected), first)
+					continue
+				}
+
+				// the other outputs were deduced along with the first one
+				require.True(t, mem.KnownValue(segmentIndex, cellsPerPoseidon+3+uint64(i)))
+				output, err := mem.Read(segmentIndex, cellsPerPoseidon+3+uint64(i))
+				require.NoError(t, err)
+				assert.Equal(t, memory.MemoryValueFromFieldElement(expected), output)
+			}
+		})
+	}
+}
+
+func TestPoseidonBuiltinInfer(t *testing.T) {
+	segment := memory.EmptySegmentWithLength(cellsPerPoseidon)
+	segment.WithBuiltinRunner(&Poseidon{})
+
+	_, err := segment.Read(2)
+	assert.ErrorContains(t, err, "cannot infer value")
+
+	one := memory.MemoryValueFromFieldElement(new(fp.Element).SetOne())
+	require.NoError(t, segment.Write(0, &one))
+	require.NoError(t, segment.Write(1, &one))
+	_, err = segment.Read(5)
+	assert.ErrorContains(t, err, "input value at offset 2 is unknown")
+}
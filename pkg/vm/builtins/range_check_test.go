@@ -36,3 +36,32 @@ func TestRangeCheckInfer(t *testing.T) {
 	segment := memory.EmptySegmentWithLength(3)
 	assert.ErrorContains(t, builtin.InferValue(segment, 0), "cannot infer value")
 }
+
+func TestRangeCheckSegmentWrite(t *testing.T) {
+	mem := memory.InitializeEmptyMemory()
+	segmentIndex := uint64(mem.AllocateBuiltinSegment(&RangeCheck{}))
+
+	inRange := memory.MemoryValueFromFieldElement(new(fp.Element).SetUint64(5))
+	require.NoError(t, mem.Write(segmentIndex, 0, &inRange))
+
+	maxValueFelt, err := new(fp.Element).SetString("0xffffffffffffffffffffffffffffffff")
+	require.NoError(t, err)
+	maxValue := memory.MemoryValueFromFieldElement(maxValueFelt)
+	require.NoError(t, mem.Write(segmentIndex, 1, &maxValue))
+
+	outOfRangeFelt, err := new(fp.Element).SetString("0x100000000000000000000000000000000")
+	require.NoError(t, err)
+	outOfRange := memory.MemoryValueFromFieldElement(outOfRangeFelt)
+	require.ErrorContains(t, mem.Write(segmentIndex, 2, &outOfRange), "segment 0, offset 2: check write")
+
+	address := memory.MemoryValueFromSegmentAndOffset(0, 0)
+	require.ErrorContains(t, mem.Write(segmentIndex, 3, &address), "segment 0, offset 3: check write")
+
+	// rejected values are not written
+	segment := mem.Segments[segmentIndex]
+	require.Equal(t, uint64(2), segment.Len())
+	for _, offset := range []uint64{2, 3} {
+		value := segment.Peek(offset)
+		require.False(t, value.Known())
+	}
+}
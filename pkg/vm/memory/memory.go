@@ -77,21 +77,26 @@ func (segment *Segment) RealLen() uint64 {
 }
 
 // Writes a new memory value to a specified offset, errors in case of overwriting a
-// different memory value
+// different memory value or if the segment builtin rejects it, in which case the
+// value is not written
 func (segment *Segment) Write(offset uint64, value *MemoryValue) error {
 	if offset >= segment.RealLen() {
 		segment.IncreaseSegmentSize(offset + 1)
 	}
-	if offset >= segment.Len() {
-		segment.LastIndex = int(offset)
-	}
 
 	mv := &segment.Data[offset]
 	if mv.Known() && !mv.Equal(value) {
 		return fmt.Errorf("rewriting value: old value: %s, new value: %s", mv, value)
 	}
+	if err := segment.BuiltinRunner.CheckWrite(segment, offset, value); err != nil {
+		return err
+	}
+
+	if offset >= segment.Len() {
+		segment.LastIndex = int(offset)
+	}
 	segment.Data[offset] = *value
-	return segment.BuiltinRunner.CheckWrite(segment, offset, value)
+	return nil
 }
 
 // Reads a memory value from a specified offset at the segment
@@ -282,6 +287,64 @@ func (memory *Memory) KnownValueAtAddress(address *MemoryAddress) bool {
 	return memory.KnownValue(address.SegmentIndex, address.Offset)
 }
 
+// Visits every known cell in memory ordered by segment index and then by offset,
+// calling fn with each one of them. The iteration stops as soon as fn returns false
+func (memory *Memory) Iterate(fn func(segment int, offset uint64, value MemoryValue) bool) {
+	for i, segment := range memory.Segments {
+		for j := uint64(0); j < segment.Len(); j++ {
+			if !segment.Data[j].Known() {
+				continue
+			}
+			if !fn(i, j, segment.Data[j]) {
+				return
+			}
+		}
+	}
+}
+
+// Drops the temporary segments left empty, such as the ones whose content was already
+// relocated somewhere else, so they don't take space in the relocated memory. The
+// program and execution segments, builtin segments and segments some address still
+// points to are always kept. Remaining segments are reindexed preserving their order
+// and every address in memory is updated accordingly. It returns the new index of
+// every segment, -1 if it was dropped, which callers must use to update any address
+// they hold outside of memory, see VirtualMachine.CompactSegments
+func (memory *Memory) CompactSegments() []int {
+	referenced := make([]bool, len(memory.Segments))
+	memory.Iterate(func(_ int, _ uint64, value MemoryValue) bool {
+		if value.IsAddress() && value.addrUnsafe().SegmentIndex < uint64(len(referenced)) {
+			referenced[value.addrUnsafe().SegmentIndex] = true
+		}
+		return true
+	})
+
+	newIndices := make([]int, len(memory.Segments))
+	segments := make([]*Segment, 0, len(memory.Segments))
+	for i, segment := range memory.Segments {
+		// segments 0 and 1 are the program and execution segments
+		_, noBuiltin := segment.BuiltinRunner.(*NoBuiltin)
+		if i > 1 && segment.Len() == 0 && !referenced[i] && noBuiltin {
+			newIndices[i] = -1
+			continue
+		}
+		newIndices[i] = len(segments)
+		segments = append(segments, segment)
+	}
+	memory.Segments = segments
+
+	memory.Iterate(func(segmentIndex int, offset uint64, value MemoryValue) bool {
+		if value.IsAddress() {
+			address := value.addrUnsafe()
+			if address.SegmentIndex < uint64(len(newIndices)) {
+				address.SegmentIndex = uint64(newIndices[address.SegmentIndex])
+				memory.Segments[segmentIndex].Data[offset] = value
+			}
+		}
+		return true
+	})
+	return newIndices
+}
+
 // It returns all segment offsets and max memory used
 func (memory *Memory) RelocationOffsets() ([]uint64, uint64) {
 	// Prover expects maxMemoryUsed to start at one
@@ -248,6 +248,75 @@ func TestRelocationOffsets(t *testing.T) {
 	assert.Equal(t, memoryUsed, uint64(7))
 }
 
+func TestMemoryIterate(t *testing.T) {
+	memory := InitializeEmptyMemory()
+	memory.AllocateEmptySegment()
+	memory.AllocateEmptySegment()
+	memory.AllocateEmptySegment()
+
+	require.NoError(t, memory.Write(0, 0, memoryValuePointerFromInt(1)))
+	require.NoError(t, memory.Write(0, 2, memoryValuePointerFromInt(2)))
+	require.NoError(t, memory.Write(2, 1, memoryValuePointerFromInt(3)))
+	address := MemoryValueFromSegmentAndOffset(0, 2)
+	require.NoError(t, memory.Write(2, 3, &address))
+
+	type cell struct {
+		segment int
+		offset  uint64
+		value   MemoryValue
+	}
+
+	cells := []cell{}
+	memory.Iterate(func(segment int, offset uint64, value MemoryValue) bool {
+		cells = append(cells, cell{segment, offset, value})
+		return true
+	})
+	assert.Equal(t, []cell{
+		{0, 0, MemoryValueFromInt(1)},
+		{0, 2, MemoryValueFromInt(2)},
+		{2, 1, MemoryValueFromInt(3)},
+		{2, 3, address},
+	}, cells)
+
+	// stops as soon as the callback returns false
+	visited := 0
+	memory.Iterate(func(segment int, offset uint64, value MemoryValue) bool {
+		visited++
+		return visited < 2
+	})
+	assert.Equal(t, 2, visited)
+}
+
+func TestCompactSegments(t *testing.T) {
+	memory := InitializeEmptyMemory()
+	memory.AllocateEmptySegment()                 // program
+	memory.AllocateEmptySegment()                 // execution
+	memory.AllocateEmptySegment()                 // 2: empty temporary segment
+	memory.AllocateEmptySegment()                 // 3: segment with data
+	memory.AllocateEmptySegment()                 // 4: empty temporary segment
+	memory.AllocateEmptySegment()                 // 5: empty but referenced segment
+	memory.AllocateBuiltinSegment(&testBuiltin{}) // 6: empty builtin segment
+
+	require.NoError(t, memory.Write(3, 0, memoryValuePointerFromInt(7)))
+	toData := MemoryValueFromSegmentAndOffset(3, 0)
+	require.NoError(t, memory.Write(1, 0, &toData))
+	toReferenced := MemoryValueFromSegmentAndOffset(5, 2)
+	require.NoError(t, memory.Write(1, 1, &toReferenced))
+	toExecution := MemoryValueFromSegmentAndOffset(1, 1)
+	require.NoError(t, memory.Write(3, 1, &toExecution))
+
+	newIndices := memory.CompactSegments()
+	assert.Equal(t, []int{0, 1, -1, 2, -1, 3, 4}, newIndices)
+	require.Len(t, memory.Segments, 5)
+
+	// every pointer keeps pointing to the same cell
+	assert.Equal(t, MemoryValueFromSegmentAndOffset(2, 0), memory.Segments[1].Data[0])
+	assert.Equal(t, MemoryValueFromSegmentAndOffset(3, 2), memory.Segments[1].Data[1])
+	assert.Equal(t, MemoryValueFromSegmentAndOffset(1, 1), memory.Segments[2].Data[1])
+	assert.Equal(t, MemoryValueFromInt(7), memory.Segments[2].Data[0])
+	assert.Equal(t, "test_builtin", memory.Segments[4].BuiltinRunner.String())
+}
+
 // compares the memory value match an expected value at the given segment and offset
 func noErrorAndEqualSegmentRead(t *testing.T, s *Segment, offset uint64, expected MemoryValue) {
 	v, err := s.Read(offset)
@@ -1,11 +1,14 @@
 package vm
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
+	"io"
 
 	a "github.com/NethermindEth/cairo-vm-go/pkg/assembler"
 	safemath "github.com/NethermindEth/cairo-vm-go/pkg/safemath"
+	"github.com/NethermindEth/cairo-vm-go/pkg/vm/builtins"
 	mem "github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
 	f "github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
 )
@@ -51,14 +54,14 @@ func (ctx *Context) AddressPc() mem.MemoryAddress {
 	return mem.MemoryAddress{SegmentIndex: ctx.Pc.SegmentIndex, Offset: ctx.Pc.Offset}
 }
 
-// relocates pc, ap and fp to be their real address value
-// that is, pc + 1, ap + programSegmentOffset, fp + programSegmentOffset
-func (ctx *Context) Relocate(executionSegmentOffset uint64) Trace {
+// relocates pc, ap and fp to be their real address value, given the offset each
+// segment starts at once memory is relocated. ap and fp always point to the
+// execution segment
+func (ctx *Context) Relocate(segmentsOffsets []uint64) Trace {
 	return Trace{
-		// todo(rodro): this should be improved upon
-		Pc: ctx.Pc.Offset + 1,
-		Ap: ctx.Ap + executionSegmentOffset,
-		Fp: ctx.Fp + executionSegmentOffset,
+		Pc: segmentsOffsets[ctx.Pc.SegmentIndex] + ctx.Pc.Offset,
+		Ap: segmentsOffsets[ExecutionSegment] + ctx.Ap,
+		Fp: segmentsOffsets[ExecutionSegment] + ctx.Fp,
 	}
 }
 
@@ -80,8 +83,8 @@ type VirtualMachine struct {
 	Step    uint64
 	Trace   []Context
 	config  VirtualMachineConfig
-	// instructions cache
-	instructions map[uint64]*a.Instruction
+	// instructions cache, keyed by address since several segments can hold a program
+	instructions map[mem.MemoryAddress]*a.Instruction
 }
 
 // NewVirtualMachine creates a VM from the program bytecode using a specified config.
@@ -99,7 +102,7 @@ func NewVirtualMachine(
 		Memory:       memory,
 		Trace:        trace,
 		config:       config,
-		instructions: make(map[uint64]*a.Instruction),
+		instructions: make(map[mem.MemoryAddress]*a.Instruction),
 	}, nil
 }
 
@@ -111,7 +114,7 @@ func (vm *VirtualMachine) RunStep(hintRunner HintRunner) error {
 	}
 
 	// if instruction is not in cache, redecode and store it
-	instruction, ok := vm.instructions[vm.Context.Pc.Offset]
+	instruction, ok := vm.instructions[vm.Context.Pc]
 	if !ok {
 		memoryValue, err := vm.Memory.ReadFromAddress(&vm.Context.Pc)
 		if err != nil {
@@ -127,7 +130,7 @@ func (vm *VirtualMachine) RunStep(hintRunner HintRunner) error {
 		if err != nil {
 			return fmt.Errorf("decoding instruction: %w", err)
 		}
-		vm.instructions[vm.Context.Pc.Offset] = instruction
+		vm.instructions[vm.Context.Pc] = instruction
 	}
 
 	// store the trace before state change
@@ -198,13 +201,41 @@ func (vm *VirtualMachine) RunInstruction(instruction *a.Instruction) error {
 	return nil
 }
 
-// It returns the current trace entry, the public memory, and the occurrence of an error
-func (vm *VirtualMachine) ExecutionTrace() ([]Trace, error) {
+// Returns the trace recorded while running in proof mode, one entry per step taken,
+// with its registers relocated into the same contiguous address space as the relocated
+// memory, which is the layout the prover expects
+func (vm *VirtualMachine) RelocateTrace() ([]Trace, error) {
 	if !vm.config.ProofMode {
 		return nil, fmt.Errorf("proof mode is off")
 	}
 
-	return vm.relocateTrace(), nil
+	segmentsOffsets, _ := vm.Memory.RelocationOffsets()
+	relocatedTrace := make([]Trace, len(vm.Trace))
+	for i := range vm.Trace {
+		if vm.Trace[i].Pc.SegmentIndex >= uint64(len(vm.Memory.Segments)) {
+			return nil, fmt.Errorf(
+				"relocate trace entry %d: pc %s points to an unallocated segment",
+				i, vm.Trace[i].Pc,
+			)
+		}
+		relocatedTrace[i] = vm.Trace[i].Relocate(segmentsOffsets)
+	}
+	return relocatedTrace, nil
+}
+
+// Drops the empty temporary segments as Memory.CompactSegments does, also updating the
+// pc and the recorded trace to the new segment indices. Registers only ever point to
+// segments holding instructions, which are never dropped. It returns the new index of
+// every segment, -1 if it was dropped
+func (vm *VirtualMachine) CompactSegments() []int {
+	newIndices := vm.Memory.CompactSegments()
+	vm.Context.Pc.SegmentIndex = uint64(newIndices[vm.Context.Pc.SegmentIndex])
+	for i := range vm.Trace {
+		vm.Trace[i].Pc.SegmentIndex = uint64(newIndices[vm.Trace[i].Pc.SegmentIndex])
+	}
+	// the cached instructions are keyed by their old address
+	vm.instructions = make(map[mem.MemoryAddress]*a.Instruction)
+	return newIndices
 }
 
 func (vm *VirtualMachine) getDstAddr(instruction *a.Instruction) (mem.MemoryAddress, error) {
@@ -504,21 +535,11 @@ func (vm *VirtualMachine) updateFp(instruction *a.Instruction, dstAddr *mem.Memo
 	}
 }
 
-func (vm *VirtualMachine) relocateTrace() []Trace {
-	// one is added, because prover expect that the first element to be on
-	// indexed on 1 instead of 0
-	relocatedTrace := make([]Trace, len(vm.Trace))
-	totalBytecode := vm.Memory.Segments[ProgramSegment].Len() + 1
-	for i := range vm.Trace {
-		relocatedTrace[i] = vm.Trace[i].Relocate(totalBytecode)
-	}
-	return relocatedTrace
-}
-
 // It returns all segments in memory but relocated as a single segment
 // Each element is a pointer to a field element, if the cell was not accessed,
-// nil is stored instead
-func (vm *VirtualMachine) RelocateMemory() []*f.Element {
+// nil is stored instead. Errors if an address points to an unallocated segment,
+// since it can't be relocated
+func (vm *VirtualMachine) RelocateMemory() ([]*f.Element, error) {
 	segmentsOffsets, maxMemoryUsed := vm.Memory.RelocationOffsets()
 	// the prover expect first element of the relocated memory to start at index 1,
 	// this way we fill relocatedMemory starting from zero, but the actual value
@@ -534,6 +555,12 @@ func (vm *VirtualMachine) RelocateMemory() []*f.Element {
 			var felt *f.Element
 			if cell.IsAddress() {
 				addr, _ := cell.MemoryAddress()
+				if addr.SegmentIndex >= uint64(len(vm.Memory.Segments)) {
+					return nil, fmt.Errorf(
+						"relocate segment %d, offset %d: address %s points to an unallocated segment",
+						i, j, addr,
+					)
+				}
 				felt = addr.Relocate(segmentsOffsets)
 			} else {
 				felt, _ = cell.FieldElement()
@@ -542,7 +569,29 @@ func (vm *VirtualMachine) RelocateMemory() []*f.Element {
 			relocatedMemory[segmentsOffsets[i]+j] = felt
 		}
 	}
-	return relocatedMemory
+	return relocatedMemory, nil
+}
+
+// Returns the contents of the output builtin segment in order, which are the values
+// the program outputs. Cells the program never wrote are returned as zero. It is empty
+// if the program doesn't use the output builtin
+func (vm *VirtualMachine) GetOutput() []f.Element {
+	outputSegment, ok := vm.Memory.FindSegmentWithBuiltin(builtins.OutputName)
+	if !ok {
+		return []f.Element{}
+	}
+
+	output := make([]f.Element, outputSegment.Len())
+	for offset := range output {
+		value := outputSegment.Peek(uint64(offset))
+		if !value.Known() {
+			continue
+		}
+		// only felts can be written to the output segment
+		felt, _ := value.FieldElement()
+		output[offset] = *felt
+	}
+	return output
 }
 
 const ctxSize = 3 * 8
@@ -608,22 +657,237 @@ func EncodeMemory(memory []*f.Element) []byte {
 	return content
 }
 
-func DecodeMemory(content []byte) []*f.Element {
-	// calculate the max memory index
-	lastContentInd := len(content) - (addrSize + feltSize)
-	lasMemIndex := binary.LittleEndian.Uint64(content[lastContentInd : lastContentInd+addrSize])
+// Decodes memory in the (address, value) form written by EncodeMemory, returning the
+// value stored at every relocated address. Errors if the last entry is truncated, if an
+// address appears twice or if a value doesn't fit in the field
+func DecodeMemory(r io.Reader) (map[uint64]*f.Element, error) {
+	memory := make(map[uint64]*f.Element)
+	entry := [addrSize + feltSize]byte{}
+	for i := 0; ; i++ {
+		_, err := io.ReadFull(r, entry[:])
+		if err == io.EOF {
+			return memory, nil
+		}
+		if err == io.ErrUnexpectedEOF {
+			return nil, fmt.Errorf("memory entry %d is truncated", i)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read memory entry %d: %w", i, err)
+		}
+
+		address := binary.LittleEndian.Uint64(entry[:addrSize])
+		if _, ok := memory[address]; ok {
+			return nil, fmt.Errorf("memory entry %d: address %d is repeated", i, address)
+		}
+		felt, err := f.LittleEndian.Element((*[feltSize]byte)(entry[addrSize:]))
+		if err != nil {
+			return nil, fmt.Errorf("memory entry %d: value at address %d exceeds the field modulus", i, address)
+		}
+		memory[address] = &felt
+	}
+}
+
+const (
+	unknownCellTag byte = iota
+	feltCellTag
+	addressCellTag
+)
 
-	// create the memory array with the same length as the max memory index
-	memory := make([]*f.Element, lasMemIndex+1)
+// Serializes the registers, the step count, the trace and the whole memory of the vm,
+// keeping every relocatable value and segment as it is, so the run can be resumed
+// later by calling UnmarshalState. The state of hints and builtin runners lives
+// outside the vm, so the runner serializes it alongside
+func (vm *VirtualMachine) MarshalState() []byte {
+	content := make([]byte, 0)
+	content = appendContext(content, &vm.Context)
+	content = binary.LittleEndian.AppendUint64(content, vm.Step)
 
-	// decode the encontent and store it in memory
-	for i := 0; i < len(content); i += addrSize + feltSize {
-		memIndex := binary.LittleEndian.Uint64(content[i : i+addrSize])
-		felt, err := f.LittleEndian.Element((*[32]byte)(content[i+addrSize : i+addrSize+feltSize]))
+	content = binary.LittleEndian.AppendUint64(content, uint64(len(vm.Trace)))
+	for i := range vm.Trace {
+		content = appendContext(content, &vm.Trace[i])
+	}
+
+	content = binary.LittleEndian.AppendUint64(content, uint64(len(vm.Memory.Segments)))
+	for _, segment := range vm.Memory.Segments {
+		builtin := segment.BuiltinRunner.String()
+		content = binary.LittleEndian.AppendUint64(content, uint64(len(builtin)))
+		content = append(content, builtin...)
+		content = binary.LittleEndian.AppendUint64(content, uint64(segment.LastIndex))
+		content = binary.LittleEndian.AppendUint64(content, segment.RealLen())
+		for j := range segment.Data {
+			content = appendMemoryValue(content, &segment.Data[j])
+		}
+	}
+	return content
+}
+
+// Restores a state serialized with MarshalState. Builtin runners cannot be serialized,
+// so the segments of the state that belong to a builtin are expected to already exist
+// in the vm memory with the same builtin runner, as it happens when the vm is
+// initialized again from the same program. Any other segment gets replaced
+func (vm *VirtualMachine) UnmarshalState(content []byte) error {
+	reader := bytes.NewReader(content)
+
+	ctx, err := readContext(reader)
+	if err != nil {
+		return fmt.Errorf("read context: %w", err)
+	}
+	var step uint64
+	if err := binary.Read(reader, binary.LittleEndian, &step); err != nil {
+		return fmt.Errorf("read step: %w", err)
+	}
+
+	var traceLength uint64
+	if err := binary.Read(reader, binary.LittleEndian, &traceLength); err != nil {
+		return fmt.Errorf("read trace length: %w", err)
+	}
+	var trace []Context
+	if vm.config.ProofMode || traceLength > 0 {
+		trace = make([]Context, 0, traceLength)
+	}
+	for i := uint64(0); i < traceLength; i++ {
+		entry, err := readContext(reader)
+		if err != nil {
+			return fmt.Errorf("read trace entry %d: %w", i, err)
+		}
+		trace = append(trace, entry)
+	}
+
+	var segmentsCount uint64
+	if err := binary.Read(reader, binary.LittleEndian, &segmentsCount); err != nil {
+		return fmt.Errorf("read segments count: %w", err)
+	}
+	segments := make([]*mem.Segment, segmentsCount)
+	for i := range segments {
+		segment, err := vm.readSegment(reader, uint64(i))
+		if err != nil {
+			return fmt.Errorf("read segment %d: %w", i, err)
+		}
+		segments[i] = segment
+	}
+
+	if reader.Len() != 0 {
+		return fmt.Errorf("%d unexpected trailing bytes", reader.Len())
+	}
+
+	vm.Context = ctx
+	vm.Step = step
+	vm.Trace = trace
+	vm.Memory.Segments = segments
+	// the program segment may have changed
+	vm.instructions = make(map[mem.MemoryAddress]*a.Instruction)
+	return nil
+}
+
+func (vm *VirtualMachine) readSegment(reader *bytes.Reader, index uint64) (*mem.Segment, error) {
+	var nameLength uint64
+	if err := binary.Read(reader, binary.LittleEndian, &nameLength); err != nil {
+		return nil, err
+	}
+	if nameLength > uint64(reader.Len()) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	name := make([]byte, nameLength)
+	if _, err := io.ReadFull(reader, name); err != nil {
+		return nil, err
+	}
+
+	segment := mem.EmptySegment()
+	if len(name) > 0 {
+		if index >= uint64(len(vm.Memory.Segments)) ||
+			vm.Memory.Segments[index].BuiltinRunner.String() != string(name) {
+			return nil, fmt.Errorf("cannot restore the %s builtin runner", name)
+		}
+		segment.BuiltinRunner = vm.Memory.Segments[index].BuiltinRunner
+	}
+
+	var lastIndex, length uint64
+	if err := binary.Read(reader, binary.LittleEndian, &lastIndex); err != nil {
+		return nil, err
+	}
+	if err := binary.Read(reader, binary.LittleEndian, &length); err != nil {
+		return nil, err
+	}
+	// every cell takes at least one byte
+	if length > uint64(reader.Len()) {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	segment.LastIndex = int(lastIndex)
+	segment.Data = make([]mem.MemoryValue, length)
+	for j := range segment.Data {
+		value, err := readMemoryValue(reader)
 		if err != nil {
-			panic(err)
+			return nil, fmt.Errorf("read cell %d: %w", j, err)
 		}
-		memory[memIndex] = &felt
+		segment.Data[j] = value
+	}
+	return segment, nil
+}
+
+func appendContext(content []byte, ctx *Context) []byte {
+	content = binary.LittleEndian.AppendUint64(content, ctx.Pc.SegmentIndex)
+	content = binary.LittleEndian.AppendUint64(content, ctx.Pc.Offset)
+	content = binary.LittleEndian.AppendUint64(content, ctx.Ap)
+	return binary.LittleEndian.AppendUint64(content, ctx.Fp)
+}
+
+func readContext(reader io.Reader) (Context, error) {
+	var registers [4]uint64
+	if err := binary.Read(reader, binary.LittleEndian, &registers); err != nil {
+		return Context{}, err
+	}
+	return Context{
+		Pc: mem.MemoryAddress{SegmentIndex: registers[0], Offset: registers[1]},
+		Ap: registers[2],
+		Fp: registers[3],
+	}, nil
+}
+
+func appendMemoryValue(content []byte, value *mem.MemoryValue) []byte {
+	switch {
+	case value.IsAddress():
+		address, _ := value.MemoryAddress()
+		content = append(content, addressCellTag)
+		content = binary.LittleEndian.AppendUint64(content, address.SegmentIndex)
+		return binary.LittleEndian.AppendUint64(content, address.Offset)
+	case value.IsFelt():
+		felt, _ := value.FieldElement()
+		var feltBytes [feltSize]byte
+		f.LittleEndian.PutElement(&feltBytes, *felt)
+		content = append(content, feltCellTag)
+		return append(content, feltBytes[:]...)
+	default:
+		return append(content, unknownCellTag)
+	}
+}
+
+func readMemoryValue(reader io.Reader) (mem.MemoryValue, error) {
+	var tag byte
+	if err := binary.Read(reader, binary.LittleEndian, &tag); err != nil {
+		return mem.UnknownValue, err
+	}
+
+	switch tag {
+	case unknownCellTag:
+		return mem.UnknownValue, nil
+	case feltCellTag:
+		var feltBytes [feltSize]byte
+		if _, err := io.ReadFull(reader, feltBytes[:]); err != nil {
+			return mem.UnknownValue, err
+		}
+		felt, err := f.LittleEndian.Element(&feltBytes)
+		if err != nil {
+			return mem.UnknownValue, err
+		}
+		return mem.MemoryValueFromFieldElement(&felt), nil
+	case addressCellTag:
+		var address mem.MemoryAddress
+		if err := binary.Read(reader, binary.LittleEndian, &address); err != nil {
+			return mem.UnknownValue, err
+		}
+		return mem.MemoryValueFromMemoryAddress(&address), nil
+	default:
+		return mem.UnknownValue, fmt.Errorf("unknown cell tag %d", tag)
 	}
-	return memory
 }
@@ -1,6 +1,7 @@
 package vm
 
 import (
+	"bytes"
 	"encoding/binary"
 	"testing"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	a "github.com/NethermindEth/cairo-vm-go/pkg/assembler"
+	"github.com/NethermindEth/cairo-vm-go/pkg/vm/builtins"
 	mem "github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
 )
 
@@ -984,7 +986,8 @@ func TestMemoryRelocationWithFelt(t *testing.T) {
 		},
 	)
 
-	res := vm.RelocateMemory()
+	res, err := vm.RelocateMemory()
+	require.NoError(t, err)
 
 	expected := []*f.Element{
 		nil,
@@ -1045,7 +1048,8 @@ func TestMemoryRelocationWithAddress(t *testing.T) {
 		},
 	)
 
-	res := vm.RelocateMemory()
+	res, err := vm.RelocateMemory()
+	require.NoError(t, err)
 
 	expected := []*f.Element{
 		nil,
@@ -1076,6 +1080,96 @@ func TestMemoryRelocationWithAddress(t *testing.T) {
 	require.Equal(t, expected, res)
 }
 
+func TestMemoryRelocationUnallocatedSegment(t *testing.T) {
+	vm := defaultVirtualMachine()
+	updateMemoryWithValues(
+		vm.Memory,
+		[]memoryWrite{
+			{0, 0, uint64(3)},
+			{1, 2, &mem.MemoryAddress{SegmentIndex: 0, Offset: 0}},
+		},
+	)
+	unallocated := mem.MemoryValueFromSegmentAndOffset(100, 0)
+	require.NoError(t, vm.Memory.Write(1, 3, &unallocated))
+
+	_, err := vm.RelocateMemory()
+	require.ErrorContains(t, err, "relocate segment 1, offset 3: address 100:0 points to an unallocated segment")
+}
+
+func TestRelocateTrace(t *testing.T) {
+	bytecode, err := a.CasmToBytecode(`
+        [ap] = 5, ap++;
+        [ap] = [ap - 1] * 2, ap++;
+        jmp rel 0;
+    `)
+	require.NoError(t, err)
+
+	memory := mem.InitializeEmptyMemory()
+	_, err = memory.AllocateSegment(bytecode)
+	require.NoError(t, err)
+	memory.AllocateEmptySegment()
+
+	vm, err := NewVirtualMachine(Context{Ap: 1, Fp: 1}, memory, VirtualMachineConfig{ProofMode: true})
+	require.NoError(t, err)
+	for i := 0; i < 4; i++ {
+		require.NoError(t, vm.RunStep(&noHintRunner{}))
+	}
+
+	// every instruction takes two cells, so the program segment takes the 6 cells
+	// starting at 1 and the execution segment starts at 7
+	expected := []Trace{
+		{Pc: 1, Ap: 8, Fp: 8},
+		{Pc: 3, Ap: 9, Fp: 8},
+		{Pc: 5, Ap: 10, Fp: 8},
+		{Pc: 5, Ap: 10, Fp: 8},
+	}
+	trace, err := vm.RelocateTrace()
+	require.NoError(t, err)
+	require.Equal(t, expected, trace)
+}
+
+func TestCompactSegmentsRemapsPc(t *testing.T) {
+	bytecode, err := a.CasmToBytecode(`
+        [ap] = 5, ap++;
+        jmp rel 0;
+    `)
+	require.NoError(t, err)
+
+	memory := mem.InitializeEmptyMemory()
+	memory.AllocateEmptySegment() // program
+	memory.AllocateEmptySegment() // execution
+	memory.AllocateEmptySegment() // empty temporary segment
+	codeSegment, err := memory.AllocateSegment(bytecode)
+	require.NoError(t, err)
+
+	vm, err := NewVirtualMachine(
+		Context{Pc: mem.MemoryAddress{SegmentIndex: uint64(codeSegment), Offset: 0}, Ap: 1, Fp: 1},
+		memory,
+		VirtualMachineConfig{ProofMode: true},
+	)
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		require.NoError(t, vm.RunStep(&noHintRunner{}))
+	}
+
+	newIndices := vm.CompactSegments()
+	require.Equal(t, []int{0, 1, -1, 2}, newIndices)
+
+	require.Equal(t, mem.MemoryAddress{SegmentIndex: 2, Offset: 2}, vm.Context.Pc)
+	require.Equal(t, mem.MemoryAddress{SegmentIndex: 2, Offset: 0}, vm.Trace[0].Pc)
+	require.Equal(t, mem.MemoryAddress{SegmentIndex: 2, Offset: 2}, vm.Trace[1].Pc)
+
+	// the vm keeps running from the remapped pc
+	require.NoError(t, vm.RunStep(&noHintRunner{}))
+	require.Equal(t, mem.MemoryAddress{SegmentIndex: 2, Offset: 2}, vm.Context.Pc)
+}
+
+func TestRelocateTraceProofModeOff(t *testing.T) {
+	vm := defaultVirtualMachine()
+	_, err := vm.RelocateTrace()
+	require.ErrorContains(t, err, "proof mode is off")
+}
+
 // ==============================
 // Test Trace and Memory Encoding
 // ==============================
@@ -1162,14 +1256,124 @@ func TestMemoryEncodingDecoding(t *testing.T) {
 	)
 
 	// testing decoding
-	decodedMemory := DecodeMemory(encodedMemory)
+	decodedMemory, err := DecodeMemory(bytes.NewReader(encodedMemory))
+	require.NoError(t, err)
 	require.Equal(
 		t,
-		memory,
+		map[uint64]*f.Element{
+			0: memory[0],
+			1: memory[1],
+			4: memory[4],
+			6: memory[6],
+		},
 		decodedMemory,
 	)
 }
 
+func TestDecodeMemoryErrors(t *testing.T) {
+	encodedMemory := EncodeMemory([]*f.Element{
+		new(f.Element).SetUint64(4),
+		new(f.Element).SetUint64(15),
+	})
+
+	decodedMemory, err := DecodeMemory(bytes.NewReader(nil))
+	require.NoError(t, err)
+	require.Empty(t, decodedMemory)
+
+	_, err = DecodeMemory(bytes.NewReader(encodedMemory[:len(encodedMemory)-1]))
+	require.ErrorContains(t, err, "memory entry 1 is truncated")
+
+	// the second value is set to 2**256 - 1
+	corrupted := append([]byte{}, encodedMemory...)
+	for i := addrSize + feltSize + addrSize; i < len(corrupted); i++ {
+		corrupted[i] = 0xff
+	}
+	_, err = DecodeMemory(bytes.NewReader(corrupted))
+	require.ErrorContains(t, err, "memory entry 1: value at address 1 exceeds the field modulus")
+
+	// the second entry points to the same address as the first one
+	repeated := append([]byte{}, encodedMemory...)
+	binary.LittleEndian.PutUint64(repeated[addrSize+feltSize:], 0)
+	_, err = DecodeMemory(bytes.NewReader(repeated))
+	require.ErrorContains(t, err, "memory entry 1: address 0 is repeated")
+}
+
+func TestMarshalUnmarshalState(t *testing.T) {
+	code := `
+        [ap] = 1, ap++;
+        call rel 4;
+        jmp rel 0;
+        [ap] = [ap - 3] * 3, ap++;
+        [ap] = [ap - 1] * 3, ap++;
+        [ap] = [ap - 1] * 3, ap++;
+        ret;
+    `
+	newVirtualMachine := func() *VirtualMachine {
+		bytecode, err := a.CasmToBytecode(code)
+		require.NoError(t, err)
+
+		memory := mem.InitializeEmptyMemory()
+		_, err = memory.AllocateSegment(bytecode)
+		require.NoError(t, err)
+		memory.AllocateEmptySegment()
+		memory.AllocateBuiltinSegment(&builtins.Output{})
+
+		vm, err := NewVirtualMachine(Context{Ap: 2, Fp: 2}, memory, VirtualMachineConfig{ProofMode: true})
+		require.NoError(t, err)
+		return vm
+	}
+	runSteps := func(vm *VirtualMachine, steps int) {
+		for i := 0; i < steps; i++ {
+			require.NoError(t, vm.RunStep(&noHintRunner{}))
+		}
+	}
+
+	uninterrupted := newVirtualMachine()
+	output := mem.MemoryValueFromInt(7)
+	require.NoError(t, uninterrupted.Memory.Write(2, 0, &output))
+	runSteps(uninterrupted, 6)
+
+	interrupted := newVirtualMachine()
+	require.NoError(t, interrupted.Memory.Write(2, 0, &output))
+	runSteps(interrupted, 3)
+	state := interrupted.MarshalState()
+
+	resumed := newVirtualMachine()
+	require.NoError(t, resumed.UnmarshalState(state))
+	require.Equal(t, interrupted.Context, resumed.Context)
+	require.Equal(t, interrupted.Step, resumed.Step)
+	require.Equal(t, interrupted.Trace, resumed.Trace)
+	require.Equal(t, interrupted.Memory.Segments, resumed.Memory.Segments)
+
+	runSteps(resumed, 3)
+	require.Equal(t, uninterrupted.Context, resumed.Context)
+	require.Equal(t, uninterrupted.Step, resumed.Step)
+	require.Equal(t, uninterrupted.Trace, resumed.Trace)
+	require.Equal(t, uninterrupted.Memory.Segments, resumed.Memory.Segments)
+	uninterruptedMemory, err := uninterrupted.RelocateMemory()
+	require.NoError(t, err)
+	resumedMemory, err := resumed.RelocateMemory()
+	require.NoError(t, err)
+	require.Equal(t, uninterruptedMemory, resumedMemory)
+}
+
+func TestUnmarshalStateMissingBuiltin(t *testing.T) {
+	vm := defaultVirtualMachine()
+	vm.Memory.AllocateBuiltinSegment(&builtins.Output{})
+	state := vm.MarshalState()
+
+	err := defaultVirtualMachine().UnmarshalState(state)
+	require.ErrorContains(t, err, "read segment 2: cannot restore the output builtin runner")
+}
+
+func TestUnmarshalStateTruncated(t *testing.T) {
+	vm := defaultVirtualMachineWithCode("[ap] = 1, ap++;")
+	state := vm.MarshalState()
+
+	err := defaultVirtualMachine().UnmarshalState(state[:len(state)-1])
+	require.Error(t, err)
+}
+
 // ==============
 // Util Functions
 // ==============